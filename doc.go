@@ -32,12 +32,18 @@ package go3d
 // Import all sub-packages for build
 import (
 	_ "github.com/ungerik/go3d/float64/bezier2"
+	_ "github.com/ungerik/go3d/float64/camera"
+	_ "github.com/ungerik/go3d/float64/dualquat"
 	_ "github.com/ungerik/go3d/float64/generic"
 	_ "github.com/ungerik/go3d/float64/hermit2"
 	_ "github.com/ungerik/go3d/float64/hermit3"
 	_ "github.com/ungerik/go3d/float64/mat2"
 	_ "github.com/ungerik/go3d/float64/mat3"
 	_ "github.com/ungerik/go3d/float64/mat4"
+	_ "github.com/ungerik/go3d/float64/noise"
+	_ "github.com/ungerik/go3d/float64/obb3"
+	_ "github.com/ungerik/go3d/float64/physics"
+	_ "github.com/ungerik/go3d/float64/plane"
 	_ "github.com/ungerik/go3d/float64/qbezier2"
 	_ "github.com/ungerik/go3d/float64/quaternion"
 	_ "github.com/ungerik/go3d/float64/vec2"
@@ -0,0 +1,57 @@
+// Package camera contains float64 camera controllers.
+package camera
+
+import (
+	"math"
+
+	"github.com/ungerik/go3d/float64/mat4"
+	"github.com/ungerik/go3d/float64/quaternion"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+// OrbitCamera is a camera orbiting around a target.
+// Yaw is the rotation in radians about the world Y axis,
+// Pitch the elevation in radians above the XZ plane of the target.
+// With zero Yaw and Pitch the camera is located on the positive Z axis of the target
+// looking along the negative Z axis.
+type OrbitCamera struct {
+	Yaw   float64
+	Pitch float64
+}
+
+// Rotate adds deltaYaw and deltaPitch to the angles of the camera.
+// Pitch is clamped to the range from -Pi/2 to Pi/2 so that the camera
+// stops at the poles instead of flipping over.
+func (cam *OrbitCamera) Rotate(deltaYaw, deltaPitch float64) *OrbitCamera {
+	cam.Yaw += deltaYaw
+	cam.Pitch = math.Max(-math.Pi/2, math.Min(cam.Pitch+deltaPitch, math.Pi/2))
+	return cam
+}
+
+// Orientation returns the rotation of the camera in world space.
+func (cam *OrbitCamera) Orientation() quaternion.T {
+	yaw := quaternion.FromYAxisAngle(cam.Yaw)
+	pitch := quaternion.FromXAxisAngle(-cam.Pitch)
+	return quaternion.Mul(&yaw, &pitch)
+}
+
+// Position returns the position of the camera at distance from target.
+func (cam *OrbitCamera) Position(target *vec3.T, distance float64) vec3.T {
+	q := cam.Orientation()
+	z := q.RotatedVec3(&vec3.UnitZ)
+	z.Scale(distance)
+	return vec3.Add(target, &z)
+}
+
+// ViewMatrix returns the view matrix of the camera at distance from target
+// looking at the target.
+// The view matrix is built from the orientation and stays valid at the poles.
+func (cam *OrbitCamera) ViewMatrix(target *vec3.T, distance float64) mat4.T {
+	q := cam.Orientation()
+	x := q.RotatedVec3(&vec3.UnitX)
+	y := q.RotatedVec3(&vec3.UnitY)
+	z := q.RotatedVec3(&vec3.UnitZ)
+	eye := z.Scaled(distance)
+	eye.Add(target)
+	return mat4.WorldToLocal(&eye, &x, &y, &z)
+}
@@ -0,0 +1,48 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+const EPSILON = 0.000001
+
+func TestOrbitCameraPitchClamp(t *testing.T) {
+	var cam OrbitCamera
+	cam.Rotate(0.5, 10)
+	if cam.Pitch != math.Pi/2 {
+		t.Errorf("pitch is %v, want Pi/2", cam.Pitch)
+	}
+	cam.Rotate(0, -20)
+	if cam.Pitch != -math.Pi/2 {
+		t.Errorf("pitch is %v, want -Pi/2", cam.Pitch)
+	}
+	if cam.Yaw != 0.5 {
+		t.Errorf("yaw is %v, want 0.5", cam.Yaw)
+	}
+}
+
+func TestOrbitCameraViewMatrix(t *testing.T) {
+	target := vec3.T{1, 2, 3}
+	for _, cam := range []OrbitCamera{{0, 0}, {0.7, 0.3}, {-2, -1.2}, {1, math.Pi / 2}} {
+		view := cam.ViewMatrix(&target, 5)
+		if v, want := view.MulVec3(&target), (vec3.T{0, 0, -5}); vec3.Distance(&v, &want) > EPSILON {
+			t.Errorf("camera %v transforms the target to %v, want %v", cam, v, want)
+		}
+		eye := cam.Position(&target, 5)
+		if v := view.MulVec3(&eye); v.Length() > EPSILON {
+			t.Errorf("camera %v transforms its position to %v, want the origin", cam, v)
+		}
+		if d := vec3.Distance(&eye, &target); math.Abs(d-5) > EPSILON {
+			t.Errorf("camera %v is %v away from the target, want 5", cam, d)
+		}
+	}
+
+	cam := OrbitCamera{Pitch: math.Pi / 2}
+	eye := cam.Position(&target, 5)
+	if want := (vec3.T{1, 7, 3}); vec3.Distance(&eye, &want) > EPSILON {
+		t.Errorf("camera at the pole is at %v, want %v", eye, want)
+	}
+}
@@ -0,0 +1,181 @@
+// Package dualquat contains a float64 unit dual quaternion type T and functions
+// for representing rigid transformations.
+package dualquat
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ungerik/go3d/float64/mat4"
+	"github.com/ungerik/go3d/float64/quaternion"
+	"github.com/ungerik/go3d/float64/vec3"
+	"github.com/ungerik/go3d/float64/vec4"
+)
+
+var (
+	// Ident holds an ident dual quaternion.
+	Ident = T{Real: quaternion.Ident}
+)
+
+// T represents a rigid transformation (rotation and translation)
+// as a unit dual quaternion Real + ε*Dual.
+// Real is the rotation and Dual equals 0.5 * translation * Real,
+// where the translation is a pure quaternion.
+// See http://en.wikipedia.org/wiki/Dual_quaternion
+type T struct {
+	Real quaternion.T
+	Dual quaternion.T
+}
+
+// FromRotationTranslation returns a dual quaternion
+// that first rotates by q and then translates by t.
+func FromRotationTranslation(q *quaternion.T, t *vec3.T) T {
+	tq := quaternion.T{t[0], t[1], t[2], 0}
+	dual := mul(&tq, q)
+	return T{Real: *q, Dual: scaled(&dual, 0.5)}
+}
+
+// FromMat4 returns the dual quaternion of the rigid transformation m.
+// Scaling and shearing in the rotational part of m are not supported.
+func FromMat4(m *mat4.T) T {
+	x := m[0].Vec3()
+	y := m[1].Vec3()
+	z := m[2].Vec3()
+	q := quaternion.FromBasis(&x, &y, &z)
+	t := m[3].Vec3()
+	return FromRotationTranslation(&q, &t)
+}
+
+// Parse parses T from a string. See also String()
+func Parse(s string) (r T, err error) {
+	_, err = fmt.Sscan(s,
+		&r.Real[0], &r.Real[1], &r.Real[2], &r.Real[3],
+		&r.Dual[0], &r.Dual[1], &r.Dual[2], &r.Dual[3],
+	)
+	return r, err
+}
+
+// String formats T as string. See also Parse().
+func (dq *T) String() string {
+	return dq.Real.String() + " " + dq.Dual.String()
+}
+
+// Rotation returns the rotation part of the dual quaternion.
+func (dq *T) Rotation() quaternion.T {
+	return dq.Real
+}
+
+// Translation returns the translation part of the dual quaternion.
+func (dq *T) Translation() vec3.T {
+	conj := dq.Real.Inverted()
+	t := mul(&dq.Dual, &conj)
+	return vec3.T{2 * t[0], 2 * t[1], 2 * t[2]}
+}
+
+// ToMat4 returns the transformation matrix of the dual quaternion.
+func (dq *T) ToMat4() mat4.T {
+	var m mat4.T
+	m.AssignQuaternion(&dq.Real)
+	t := dq.Translation()
+	m[3] = vec4.T{t[0], t[1], t[2], 1}
+	return m
+}
+
+// Normalize normalizes the dual quaternion to a unit dual quaternion,
+// so that Real has unit length and is orthogonal to Dual.
+func (dq *T) Normalize() *T {
+	norm := math.Sqrt(dq.Real.Norm())
+	if norm == 0 {
+		*dq = Ident
+		return dq
+	}
+	r := scaled(&dq.Real, 1/norm)
+	d := scaled(&dq.Dual, 1/norm)
+	// remove the part of Dual parallel to Real
+	dot := quaternion.Dot(&r, &d)
+	dq.Real = r
+	dq.Dual = quaternion.T{
+		d[0] - r[0]*dot,
+		d[1] - r[1]*dot,
+		d[2] - r[2]*dot,
+		d[3] - r[3]*dot,
+	}
+	return dq
+}
+
+// Normalized returns a normalized copy of the dual quaternion.
+func (dq *T) Normalized() T {
+	r := *dq
+	r.Normalize()
+	return r
+}
+
+// TransformVec3 applies the rigid transformation to v.
+func (dq *T) TransformVec3(v *vec3.T) {
+	*v = dq.TransformedVec3(v)
+}
+
+// TransformedVec3 returns a copy of v with the rigid transformation applied.
+func (dq *T) TransformedVec3(v *vec3.T) vec3.T {
+	qv := quaternion.T{v[0], v[1], v[2], 0}
+	conj := dq.Real.Inverted()
+	r := mul(&dq.Real, &qv)
+	r = mul(&r, &conj)
+	t := dq.Translation()
+	return vec3.T{r[0] + t[0], r[1] + t[1], r[2] + t[2]}
+}
+
+// Mul returns the concatenation of the transformations a and b,
+// the result applies b first and then a.
+func Mul(a, b *T) T {
+	rd := mul(&a.Real, &b.Dual)
+	dr := mul(&a.Dual, &b.Real)
+	return T{
+		Real: mul(&a.Real, &b.Real),
+		Dual: quaternion.T{rd[0] + dr[0], rd[1] + dr[1], rd[2] + dr[2], rd[3] + dr[3]},
+	}
+}
+
+// Blend blends between the rigid transformations a and b by weight (0,1)
+// using dual quaternion linear blending (DLB).
+// The result is always a rigid transformation, which avoids the
+// volume loss and candy-wrapper artifacts of blending matrices
+// in linear blend skinning.
+// b is negated if necessary so that the blend takes the shortest path.
+func Blend(a, b *T, weight float64) T {
+	wb := weight
+	if quaternion.Dot(&a.Real, &b.Real) < 0 {
+		wb = -wb
+	}
+	wa := 1 - weight
+	r := T{
+		Real: quaternion.T{
+			a.Real[0]*wa + b.Real[0]*wb,
+			a.Real[1]*wa + b.Real[1]*wb,
+			a.Real[2]*wa + b.Real[2]*wb,
+			a.Real[3]*wa + b.Real[3]*wb,
+		},
+		Dual: quaternion.T{
+			a.Dual[0]*wa + b.Dual[0]*wb,
+			a.Dual[1]*wa + b.Dual[1]*wb,
+			a.Dual[2]*wa + b.Dual[2]*wb,
+			a.Dual[3]*wa + b.Dual[3]*wb,
+		},
+	}
+	return *r.Normalize()
+}
+
+// mul is the quaternion product without the normalization of quaternion.Mul,
+// which is needed for the non unit dual part.
+func mul(a, b *quaternion.T) quaternion.T {
+	return quaternion.T{
+		a[3]*b[0] + a[0]*b[3] + a[1]*b[2] - a[2]*b[1],
+		a[3]*b[1] + a[1]*b[3] + a[2]*b[0] - a[0]*b[2],
+		a[3]*b[2] + a[2]*b[3] + a[0]*b[1] - a[1]*b[0],
+		a[3]*b[3] - a[0]*b[0] - a[1]*b[1] - a[2]*b[2],
+	}
+}
+
+func scaled(q *quaternion.T, f float64) quaternion.T {
+	return quaternion.T{q[0] * f, q[1] * f, q[2] * f, q[3] * f}
+}
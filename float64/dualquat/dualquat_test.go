@@ -0,0 +1,94 @@
+package dualquat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/mat4"
+	"github.com/ungerik/go3d/float64/quaternion"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+const EPSILON = 0.000001
+
+// isRigid returns if the rotational part of m is orthonormal without reflection.
+func isRigid(m *mat4.T, tolerance float64) bool {
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			a := m[i].Vec3()
+			b := m[j].Vec3()
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if math.Abs(vec3.Dot(&a, &b)-want) > tolerance {
+				return false
+			}
+		}
+	}
+	return m.Determinant3x3() > 0
+}
+
+func TestMat4RoundTrip(t *testing.T) {
+	q := quaternion.FromEulerAngles(0.7, -0.4, 2.1)
+	translation := vec3.T{3, -2, 5}
+	dq := FromRotationTranslation(&q, &translation)
+
+	if got := dq.Translation(); vec3.Distance(&got, &translation) > EPSILON {
+		t.Errorf("translation is %v, want %v", got, translation)
+	}
+
+	m := dq.ToMat4()
+	var want mat4.T
+	want.AssignQuaternion(&q)
+	want.SetTranslation(&translation)
+	for col := 0; col < 4; col++ {
+		for row := 0; row < 4; row++ {
+			if math.Abs(m[col][row]-want[col][row]) > EPSILON {
+				t.Fatalf("ToMat4 is %v, want %v", m, want)
+			}
+		}
+	}
+
+	back := FromMat4(&m)
+	if math.Abs(math.Abs(quaternion.Dot(&back.Real, &dq.Real))-1) > EPSILON {
+		t.Errorf("rotation after round trip is %v, want %v", back.Real, dq.Real)
+	}
+	if got := back.Translation(); vec3.Distance(&got, &translation) > EPSILON {
+		t.Errorf("translation after round trip is %v, want %v", got, translation)
+	}
+
+	p := vec3.T{1, 2, 3}
+	if got, want := dq.TransformedVec3(&p), m.MulVec3(&p); vec3.Distance(&got, &want) > EPSILON {
+		t.Errorf("transformed point is %v, want %v", got, want)
+	}
+}
+
+func TestBlend(t *testing.T) {
+	qa := quaternion.FromEulerAngles(0, 0, 0)
+	qb := quaternion.FromEulerAngles(2.5, 0.3, -0.8)
+	ta := vec3.T{0, 0, 0}
+	tb := vec3.T{4, 0, -2}
+	a := FromRotationTranslation(&qa, &ta)
+	b := FromRotationTranslation(&qb, &tb)
+
+	for _, w := range []float64{0, 0.25, 0.5, 0.75, 1} {
+		blended := Blend(&a, &b, w)
+		m := blended.ToMat4()
+		if !isRigid(&m, EPSILON) {
+			t.Errorf("blend at %v is not rigid: %v", w, m)
+		}
+	}
+
+	end := Blend(&a, &b, 1)
+	if got := end.Translation(); vec3.Distance(&got, &tb) > EPSILON {
+		t.Errorf("blend at 1 has translation %v, want %v", got, tb)
+	}
+
+	// blending pure translations interpolates linearly
+	c := FromRotationTranslation(&qa, &tb)
+	mid := Blend(&a, &c, 0.5)
+	if got, want := mid.Translation(), (vec3.T{2, 0, -1}); vec3.Distance(&got, &want) > EPSILON {
+		t.Errorf("blend of translations is %v, want %v", got, want)
+	}
+}
@@ -2,6 +2,10 @@
 // that all float64 vector and matrix types implement.
 package generic
 
+import (
+	"errors"
+)
+
 // T is an interface that all float64 vector and matrix types implement.
 type T interface {
 
@@ -23,3 +27,32 @@ type T interface {
 	// IsZero checks if all elements of the vector or matrix are zero.
 	IsZero() bool
 }
+
+// Matrix is an interface that all float64 matrix types implement.
+type Matrix interface {
+
+	// Cols returns the number of columns of the matrix.
+	Cols() int
+
+	// Rows returns the number of rows of the matrix.
+	Rows() int
+
+	// Get returns the element at column col and row row of the matrix.
+	Get(col, row int) float64
+}
+
+// MulVec multiplies the matrix m with the column vector v.
+// An error is returned if v is not a column vector
+// with as many rows as m has columns.
+func MulVec(m Matrix, v T) ([]float64, error) {
+	if v.Cols() != 1 || v.Rows() != m.Cols() {
+		return nil, errors.New("generic.MulVec: vector size does not match matrix columns")
+	}
+	result := make([]float64, m.Rows())
+	for row := range result {
+		for col := 0; col < m.Cols(); col++ {
+			result[row] += m.Get(col, row) * v.Get(0, col)
+		}
+	}
+	return result, nil
+}
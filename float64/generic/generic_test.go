@@ -0,0 +1,67 @@
+package generic_test
+
+import (
+	"testing"
+
+	"github.com/ungerik/go3d/float64/generic"
+	"github.com/ungerik/go3d/float64/mat2"
+	"github.com/ungerik/go3d/float64/mat3"
+	"github.com/ungerik/go3d/float64/mat4"
+	"github.com/ungerik/go3d/float64/vec2"
+	"github.com/ungerik/go3d/float64/vec3"
+	"github.com/ungerik/go3d/float64/vec4"
+)
+
+func sliceEqual(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMulVec(t *testing.T) {
+	m2 := mat2.T{vec2.T{1, 2}, vec2.T{3, 4}}
+	v2 := vec2.T{5, 6}
+	want2 := []float64{23, 34}
+
+	m3 := mat3.T{vec3.T{1, 2, 3}, vec3.T{4, 5, 6}, vec3.T{7, 8, 10}}
+	v3 := vec3.T{-1, 0.5, 2}
+	want3 := m3.MulVec3(&v3)
+
+	var m4 mat4.T
+	m4.AssignEulerRotation(0.3, 0.2, 0.1)
+	m4.SetTranslation(&vec3.T{1, 2, 3})
+	v4 := vec4.T{1, -2, 3, 1}
+	want4 := m4.MulVec4(&v4)
+
+	tests := []struct {
+		m    generic.Matrix
+		v    generic.T
+		want []float64
+	}{
+		{&m2, &v2, want2},
+		{&m3, &v3, want3[:]},
+		{&m4, &v4, want4[:]},
+	}
+	for _, test := range tests {
+		got, err := generic.MulVec(test.m, test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !sliceEqual(got, test.want) {
+			t.Errorf("MulVec of %v and %v is %v, want %v", test.m, test.v, got, test.want)
+		}
+	}
+
+	if _, err := generic.MulVec(&m3, &v4); err == nil {
+		t.Errorf("no error for a vec4 multiplied with a mat3")
+	}
+	if _, err := generic.MulVec(&m4, &m4); err == nil {
+		t.Errorf("no error for a matrix as vector")
+	}
+}
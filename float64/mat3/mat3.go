@@ -151,6 +151,45 @@ func (mat *T) TranslateY(dy float64) *T {
 	return mat
 }
 
+// Translation2D returns the 2D homogeneous transformation that translates by t.
+func Translation2D(t *vec2.T) T {
+	m := Ident
+	m.SetTranslation(t)
+	return m
+}
+
+// Rotation2D returns the 2D homogeneous transformation
+// that rotates counter-clockwise by angle (in radians) around the origin.
+func Rotation2D(angle float64) T {
+	sin, cos := math.Sincos(angle)
+	return T{
+		vec3.T{cos, sin, 0},
+		vec3.T{-sin, cos, 0},
+		vec3.T{0, 0, 1},
+	}
+}
+
+// Scaling2D returns the 2D homogeneous transformation that scales by s.
+func Scaling2D(s *vec2.T) T {
+	m := Ident
+	m.ScaleVec2(s)
+	return m
+}
+
+// TransformVec2 returns the 2D point v transformed by the homogeneous
+// transformation mat, treating v as vec3.T{v[0], v[1], 1}.
+// The result is divided by the resulting homogeneous component
+// if it is not 1, which is only the case for projective transformations.
+func (mat *T) TransformVec2(v *vec2.T) vec2.T {
+	x := mat[0][0]*v[0] + mat[1][0]*v[1] + mat[2][0]
+	y := mat[0][1]*v[0] + mat[1][1]*v[1] + mat[2][1]
+	w := mat[0][2]*v[0] + mat[1][2]*v[1] + mat[2][2]
+	if w != 1 && w != 0 {
+		return vec2.T{x / w, y / w}
+	}
+	return vec2.T{x, y}
+}
+
 // Trace returns the trace value for the matrix.
 func (mat *T) Trace() float64 {
 	return mat[0][0] + mat[1][1] + mat[2][2]
@@ -178,8 +217,8 @@ func (mat *T) AssignMat2x2(m *mat2.T) *T {
 func (mat *T) MulVec3(v *vec3.T) vec3.T {
 	return vec3.T{
 		mat[0][0]*v[0] + mat[1][0]*v[1] + mat[2][0]*v[2],
-		mat[0][1]*v[1] + mat[1][1]*v[1] + mat[2][1]*v[2],
-		mat[0][2]*v[2] + mat[1][2]*v[1] + mat[2][2]*v[2],
+		mat[0][1]*v[0] + mat[1][1]*v[1] + mat[2][1]*v[2],
+		mat[0][2]*v[0] + mat[1][2]*v[1] + mat[2][2]*v[2],
 	}
 }
 
@@ -210,8 +249,161 @@ func (mat *T) Quaternion() quaternion.T {
 	return q.Normalized()
 }
 
+// AxisAngle extracts the rotation of the matrix in form of a unit axis
+// and a rotation angle in the range [0,π].
+// The angle is computed from the trace and the axis from the skew symmetric part of the matrix.
+// Near π the skew symmetric part vanishes, so the axis is then
+// taken from the symmetric part instead.
+// For the identity matrix the axis is vec3.UnitX and the angle 0.
+func (mat *T) AxisAngle() (axis vec3.T, angle float64) {
+	cos := (mat.Trace() - 1) * 0.5
+	if cos > 1 {
+		cos = 1
+	} else if cos < -1 {
+		cos = -1
+	}
+	angle = math.Acos(cos)
+	skew := vec3.T{
+		mat[1][2] - mat[2][1],
+		mat[2][0] - mat[0][2],
+		mat[0][1] - mat[1][0],
+	}
+	if angle < 1e-12 {
+		return vec3.UnitX, 0
+	}
+	if angle < math.Pi-1e-3 {
+		return skew.Normalized(), angle
+	}
+	// mat = cos*I + (1-cos)*axis*axisᵀ + sin*[axis]×,
+	// use the largest diagonal element of the symmetric part for stability
+	oneMinusCos := 1 - cos
+	i := 0
+	if mat[1][1] > mat[i][i] {
+		i = 1
+	}
+	if mat[2][2] > mat[i][i] {
+		i = 2
+	}
+	axis[i] = math.Sqrt(math.Max((mat[i][i]-cos)/oneMinusCos, 0))
+	for j := 0; j < 3; j++ {
+		if j != i {
+			axis[j] = (mat[i][j] + mat[j][i]) * 0.5 / (oneMinusCos * axis[i])
+		}
+	}
+	axis.Normalize()
+	if vec3.Dot(&axis, &skew) < 0 {
+		axis.Invert()
+	}
+	return axis, angle
+}
+
+// ExpSO3 returns the rotation matrix for the rotation vector,
+// which is the rotation axis scaled by the rotation angle in radians,
+// using the Rodrigues formula I + sin(θ)/θ*K + (1-cos(θ))/θ²*K²
+// where K is the cross product matrix of the rotation vector.
+// Small angles use the Taylor expansion of the coefficients.
+func ExpSO3(rotationVector *vec3.T) T {
+	thetaSqr := rotationVector.LengthSqr()
+	var a, b float64
+	if thetaSqr < 1e-8 {
+		a = 1 - thetaSqr/6
+		b = 0.5 - thetaSqr/24
+	} else {
+		theta := math.Sqrt(thetaSqr)
+		a = math.Sin(theta) / theta
+		b = (1 - math.Cos(theta)) / thetaSqr
+	}
+	w := rotationVector
+	return T{
+		vec3.T{1 + b*(w[0]*w[0]-thetaSqr), a*w[2] + b*w[1]*w[0], -a*w[1] + b*w[2]*w[0]},
+		vec3.T{-a*w[2] + b*w[0]*w[1], 1 + b*(w[1]*w[1]-thetaSqr), a*w[0] + b*w[2]*w[1]},
+		vec3.T{a*w[1] + b*w[0]*w[2], -a*w[0] + b*w[1]*w[2], 1 + b*(w[2]*w[2]-thetaSqr)},
+	}
+}
+
+// LogSO3 returns the rotation vector of the rotation matrix,
+// which is the rotation axis scaled by the rotation angle in the range [0,π].
+// It is the inverse of ExpSO3. Small angles use the Taylor expansion
+// of θ/(2*sin(θ)) and angles near π use AxisAngle.
+func (mat *T) LogSO3() vec3.T {
+	cos := (mat.Trace() - 1) * 0.5
+	if cos > 1 {
+		cos = 1
+	} else if cos < -1 {
+		cos = -1
+	}
+	skew := vec3.T{
+		mat[1][2] - mat[2][1],
+		mat[2][0] - mat[0][2],
+		mat[0][1] - mat[1][0],
+	}
+	sin := skew.Length() * 0.5
+	theta := math.Atan2(sin, cos)
+	if theta >= math.Pi-1e-3 {
+		axis, _ := mat.AxisAngle()
+		return axis.Scaled(theta)
+	}
+	f := 0.5 + theta*theta/12
+	if theta > 1e-4 {
+		f = theta / (2 * sin)
+	}
+	return skew.Scaled(f)
+}
+
+// TwistAngle returns the angle in radians in the range [-π,π] of the twist
+// about the unit length axis when the rotation of the matrix is decomposed
+// into a swing perpendicular to axis followed by a twist about axis.
+// The matrix must be a rotation matrix.
+func (mat *T) TwistAngle(axis *vec3.T) float64 {
+	q := quaternion.FromBasis(&mat[0], &mat[1], &mat[2])
+	v := vec3.T{q[0], q[1], q[2]}
+	angle := 2 * math.Atan2(vec3.Dot(&v, axis), q[3])
+	if angle > math.Pi {
+		angle -= 2 * math.Pi
+	} else if angle < -math.Pi {
+		angle += 2 * math.Pi
+	}
+	return angle
+}
+
 // AssignQuaternion assigns a quaternion to the rotations part of the matrix and sets the other elements to their ident value.
+// q is normalized, a zero quaternion results in the ident rotation.
+// See AssignUnitQuaternion for a faster version for unit quaternions.
 func (mat *T) AssignQuaternion(q *quaternion.T) *T {
+	// scaling by 2/|q|² is the same as normalizing q first
+	var s float64
+	if norm := q.Norm(); norm != 0 {
+		s = 2 / norm
+	}
+	xx := q[0] * q[0] * s
+	yy := q[1] * q[1] * s
+	zz := q[2] * q[2] * s
+	xy := q[0] * q[1] * s
+	xz := q[0] * q[2] * s
+	yz := q[1] * q[2] * s
+	wx := q[3] * q[0] * s
+	wy := q[3] * q[1] * s
+	wz := q[3] * q[2] * s
+
+	mat[0][0] = 1 - (yy + zz)
+	mat[1][0] = xy - wz
+	mat[2][0] = xz + wy
+
+	mat[0][1] = xy + wz
+	mat[1][1] = 1 - (xx + zz)
+	mat[2][1] = yz - wx
+
+	mat[0][2] = xz - wy
+	mat[1][2] = yz + wx
+	mat[2][2] = 1 - (xx + yy)
+
+	return mat
+}
+
+// AssignUnitQuaternion assigns the unit quaternion q to the rotations part of the matrix and sets the other elements to their ident value.
+// It skips the normalization of AssignQuaternion and must only be used
+// with quaternions of unit length, other quaternions result in a scaled and sheared matrix.
+func (mat *T) AssignUnitQuaternion(q *quaternion.T) *T {
 	xx := q[0] * q[0] * 2
 	yy := q[1] * q[1] * 2
 	zz := q[2] * q[2] * 2
@@ -380,3 +572,258 @@ func (mat *T) Transpose() *T {
 	swap(&mat[2][1], &mat[1][2])
 	return mat
 }
+
+// Transposed returns a transposed copy of the matrix.
+func (mat *T) Transposed() T {
+	result := *mat
+	result.Transpose()
+	return result
+}
+
+// Invert inverts the matrix.
+// Does not check if matrix is singular and may lead to strange results!
+func (mat *T) Invert() *T {
+	// The rows of the inverse are the cross products of the columns divided by the determinant.
+	x := vec3.Cross(&mat[1], &mat[2])
+	y := vec3.Cross(&mat[2], &mat[0])
+	z := vec3.Cross(&mat[0], &mat[1])
+	ooDet := 1 / vec3.Dot(&mat[0], &x)
+	mat[0] = x.Scaled(ooDet)
+	mat[1] = y.Scaled(ooDet)
+	mat[2] = z.Scaled(ooDet)
+	return mat.Transpose()
+}
+
+// Inverted returns an inverted copy of the matrix.
+// Does not check if matrix is singular and may lead to strange results!
+func (mat *T) Inverted() T {
+	result := *mat
+	result.Invert()
+	return result
+}
+
+// PolarDecompose decomposes the matrix into an orthonormal rotation and a symmetric stretch
+// so that mat = rotation * stretch.
+// The rotation is the orthonormal matrix nearest to mat and is computed
+// with Higham's method by repeatedly averaging it with its inverse transpose.
+// The matrix must not be singular. If its determinant is negative,
+// the orthonormal part contains a reflection.
+func (mat *T) PolarDecompose() (rotation T, stretch T) {
+	rotation = *mat
+	for i := 0; i < 100; i++ {
+		invT := rotation.Inverted()
+		invT.Transpose()
+		var maxDiff float64
+		for col := range rotation {
+			for row := range rotation[col] {
+				next := 0.5 * (rotation[col][row] + invT[col][row])
+				if diff := math.Abs(next - rotation[col][row]); diff > maxDiff {
+					maxDiff = diff
+				}
+				rotation[col][row] = next
+			}
+		}
+		if maxDiff < 1e-12 {
+			break
+		}
+	}
+	rotationT := rotation.Transposed()
+	stretch.AssignMul(&rotationT, mat)
+	return rotation, stretch
+}
+
+// RotationBetweenFrames returns the rotation matrix to * fromᵀ that maps
+// the from frame to the to frame, so that the result applied to fromX gives toX,
+// to fromY gives toY and to fromZ gives toZ.
+// Both frames must be orthonormal and of the same handedness,
+// otherwise the result is not a rotation.
+func RotationBetweenFrames(fromX, fromY, fromZ, toX, toY, toZ *vec3.T) T {
+	from := [3]*vec3.T{fromX, fromY, fromZ}
+	to := [3]*vec3.T{toX, toY, toZ}
+	var r T
+	for col := 0; col < 3; col++ {
+		for row := 0; row < 3; row++ {
+			r[col][row] = to[0][row]*from[0][col] + to[1][row]*from[1][col] + to[2][row]*from[2][col]
+		}
+	}
+	return r
+}
+
+// Householder returns the Householder matrix I - 2*v*vᵀ/(vᵀ*v)
+// that reflects vectors about the plane perpendicular to v.
+// The ident matrix is returned for a zero vector.
+func Householder(v *vec3.T) T {
+	lengthSqr := v.LengthSqr()
+	if lengthSqr == 0 {
+		return Ident
+	}
+	f := -2 / lengthSqr
+	result := Ident
+	for col := range result {
+		for row := range result[col] {
+			result[col][row] += f * v[row] * v[col]
+		}
+	}
+	return result
+}
+
+// ScaleAlongAxis returns the matrix I + (factor-1)*a*aᵀ/(aᵀ*a)
+// that scales the component of vectors along axis by factor
+// and leaves the components perpendicular to axis unchanged.
+// The ident matrix is returned for a zero vector.
+func ScaleAlongAxis(axis *vec3.T, factor float64) T {
+	lengthSqr := axis.LengthSqr()
+	if lengthSqr == 0 {
+		return Ident
+	}
+	f := (factor - 1) / lengthSqr
+	result := Ident
+	for col := range result {
+		for row := range result[col] {
+			result[col][row] += f * axis[row] * axis[col]
+		}
+	}
+	return result
+}
+
+// SymmetricEigen returns the eigenvalues and the unit length eigenvectors
+// of the symmetric matrix mat using the cyclic Jacobi method.
+// The eigenvalues are sorted in descending order and vectors[i]
+// (the i-th column) is the eigenvector of values[i].
+// Only the lower triangle of the matrix is read.
+func (mat *T) SymmetricEigen() (values vec3.T, vectors T) {
+	var a [3][3]float64
+	for col := 0; col < 3; col++ {
+		for row := col; row < 3; row++ {
+			a[row][col] = mat[col][row]
+			a[col][row] = mat[col][row]
+		}
+	}
+	v := [3][3]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	for sweep := 0; sweep < 50; sweep++ {
+		off := a[0][1]*a[0][1] + a[0][2]*a[0][2] + a[1][2]*a[1][2]
+		if off < 1e-30 {
+			break
+		}
+		for p := 0; p < 3; p++ {
+			for q := p + 1; q < 3; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				for k := 0; k < 3; k++ {
+					akp, akq := a[k][p], a[k][q]
+					a[k][p] = c*akp - s*akq
+					a[k][q] = s*akp + c*akq
+				}
+				for k := 0; k < 3; k++ {
+					apk, aqk := a[p][k], a[q][k]
+					a[p][k] = c*apk - s*aqk
+					a[q][k] = s*apk + c*aqk
+				}
+				for k := 0; k < 3; k++ {
+					vkp, vkq := v[k][p], v[k][q]
+					v[k][p] = c*vkp - s*vkq
+					v[k][q] = s*vkp + c*vkq
+				}
+			}
+		}
+	}
+	for i := 0; i < 3; i++ {
+		values[i] = a[i][i]
+		vectors[i] = vec3.T{v[0][i], v[1][i], v[2][i]}
+	}
+	// sort descending by eigenvalue
+	for i := 0; i < 2; i++ {
+		for j := i + 1; j < 3; j++ {
+			if values[j] > values[i] {
+				values[i], values[j] = values[j], values[i]
+				vectors[i], vectors[j] = vectors[j], vectors[i]
+			}
+		}
+	}
+	return values, vectors
+}
+
+// ConditionNumber returns the ratio of the largest to the smallest singular value of the matrix,
+// computed from the eigenvalues of matᵀ*mat.
+// Values near 1 indicate a well-conditioned matrix,
+// large values warn that inverting the matrix is numerically unstable.
+// For a singular matrix +Inf is returned.
+func (mat *T) ConditionNumber() float64 {
+	matT := mat.Transposed()
+	var mtm T
+	mtm.AssignMul(&matT, mat)
+	values, _ := mtm.SymmetricEigen()
+	if values[2] <= 0 {
+		return math.Inf(1)
+	}
+	return math.Sqrt(values[0] / values[2])
+}
+
+// SVD returns the singular value decomposition of the matrix so that mat = u * diag(s) * vᵀ.
+// s holds the non-negative singular values in descending order,
+// the columns of u and v are the corresponding orthonormal left and right singular vectors.
+// The decomposition is computed from the symmetric eigen-decomposition of matᵀ*mat.
+// For rank deficient matrices the left singular vectors of zero singular values
+// are completed to an orthonormal basis.
+func (mat *T) SVD() (u T, s vec3.T, v T) {
+	matT := mat.Transposed()
+	var mtm T
+	mtm.AssignMul(&matT, mat)
+	values, v := mtm.SymmetricEigen()
+	for i := range s {
+		s[i] = math.Sqrt(math.Max(values[i], 0))
+	}
+
+	const epsilon = 1e-12
+	tolerance := epsilon * math.Max(s[0], epsilon)
+	for i := range u {
+		if s[i] <= tolerance {
+			s[i] = 0
+		}
+		u[i] = mat.MulVec3(&v[i])
+		// Gram-Schmidt against the previous columns for numerical stability
+		for j := 0; j < i; j++ {
+			d := u[j].Scaled(vec3.Dot(&u[i], &u[j]))
+			u[i].Sub(&d)
+		}
+		if s[i] == 0 || u[i].LengthSqr() < tolerance*tolerance {
+			switch i {
+			case 0:
+				u[0] = vec3.UnitX
+			case 1:
+				u[1] = u[0].Normal()
+			case 2:
+				u[2] = vec3.Cross(&u[0], &u[1])
+			}
+		}
+		u[i].Normalize()
+	}
+	return u, s, v
+}
+
+// PseudoInverse returns the Moore-Penrose pseudo-inverse of the matrix computed from its SVD.
+// Singular values below tolerance are treated as zero instead of being inverted,
+// so the result is well defined for singular matrices where Invert fails
+// and yields the minimum norm least squares solution x = pinv * b of mat * x = b.
+func (mat *T) PseudoInverse(tolerance float64) T {
+	u, s, v := mat.SVD()
+	for i := range v {
+		if s[i] > tolerance {
+			v[i].Scale(1 / s[i])
+		} else {
+			v[i] = vec3.Zero
+		}
+	}
+	uT := u.Transposed()
+	var result T
+	result.AssignMul(&v, &uT)
+	return result
+}
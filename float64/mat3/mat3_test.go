@@ -0,0 +1,363 @@
+package mat3
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/quaternion"
+	"github.com/ungerik/go3d/float64/vec2"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+const EPSILON = 0.000001
+
+func matEqual(a, b *T, epsilon float64) bool {
+	for col := range a {
+		for row := range a[col] {
+			if math.Abs(a[col][row]-b[col][row]) > epsilon {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestMulVec3(t *testing.T) {
+	m := T{vec3.T{1, 2, 3}, vec3.T{4, 5, 6}, vec3.T{7, 8, 9}}
+	v := vec3.T{1, 10, 100}
+	want := vec3.T{741, 852, 963}
+	if got := m.MulVec3(&v); got != want {
+		t.Errorf("MulVec3 returned %v, want %v", got, want)
+	}
+	m.TransformVec3(&v)
+	if v != want {
+		t.Errorf("TransformVec3 returned %v, want %v", v, want)
+	}
+}
+
+func TestInvert(t *testing.T) {
+	m := T{vec3.T{2, 0, 1}, vec3.T{1, 3, 0}, vec3.T{0, 1, 4}}
+	inv := m.Inverted()
+	var product T
+	product.AssignMul(&m, &inv)
+	if !matEqual(&product, &Ident, EPSILON) {
+		t.Errorf("m * m.Inverted() is %v, want identity", &product)
+	}
+}
+
+func TestPolarDecompose(t *testing.T) {
+	var r T
+	r.AssignEulerRotation(0.3, -0.7, 1.1)
+	s := T{vec3.T{2, 0.3, 0.1}, vec3.T{0.3, 1.5, -0.2}, vec3.T{0.1, -0.2, 0.8}}
+	var m T
+	m.AssignMul(&r, &s)
+
+	rotation, stretch := m.PolarDecompose()
+
+	var reconstructed T
+	reconstructed.AssignMul(&rotation, &stretch)
+	if !matEqual(&reconstructed, &m, EPSILON) {
+		t.Errorf("rotation * stretch is %v, want %v", &reconstructed, &m)
+	}
+	rotationT := rotation.Transposed()
+	var orthonormal T
+	orthonormal.AssignMul(&rotationT, &rotation)
+	if !matEqual(&orthonormal, &Ident, EPSILON) {
+		t.Errorf("rotation is not orthonormal: %v", &rotation)
+	}
+	if !matEqual(&rotation, &r, EPSILON) {
+		t.Errorf("rotation is %v, want %v", &rotation, &r)
+	}
+	if !matEqual(&stretch, &s, EPSILON) {
+		t.Errorf("stretch is %v, want %v", &stretch, &s)
+	}
+}
+
+func TestHouseholder(t *testing.T) {
+	v := vec3.T{1, 2, -2}
+	h := Householder(&v)
+	reflected := h.MulVec3(&v)
+	if want := v.Inverted(); vec3.Distance(&reflected, &want) > EPSILON {
+		t.Errorf("Householder reflected %v to %v, want %v", v, reflected, want)
+	}
+	perpendicular := vec3.T{2, 1, 2}
+	if got := h.MulVec3(&perpendicular); vec3.Distance(&got, &perpendicular) > EPSILON {
+		t.Errorf("Householder changed perpendicular vector %v to %v", perpendicular, got)
+	}
+	if h := Householder(&vec3.Zero); h != Ident {
+		t.Errorf("Householder of zero vector is %v, want identity", &h)
+	}
+}
+
+func TestSymmetricEigen(t *testing.T) {
+	m := T{vec3.T{4, 1, 0.5}, vec3.T{1, 3, -1}, vec3.T{0.5, -1, 2}}
+	values, vectors := m.SymmetricEigen()
+	if !(values[0] >= values[1] && values[1] >= values[2]) {
+		t.Errorf("eigenvalues %v are not sorted in descending order", values)
+	}
+	for i := range vectors {
+		if l := vectors[i].Length(); math.Abs(l-1) > EPSILON {
+			t.Errorf("eigenvector %d has length %v", i, l)
+		}
+		mv := m.MulVec3(&vectors[i])
+		lv := vectors[i].Scaled(values[i])
+		if vec3.Distance(&mv, &lv) > EPSILON {
+			t.Errorf("m * %v is %v, want %v", vectors[i], mv, lv)
+		}
+	}
+	if trace := values[0] + values[1] + values[2]; math.Abs(trace-m.Trace()) > EPSILON {
+		t.Errorf("sum of eigenvalues %v differs from trace %v", trace, m.Trace())
+	}
+}
+
+func TestConditionNumber(t *testing.T) {
+	var r T
+	r.AssignEulerRotation(0.4, 1.2, -0.8)
+	if c := r.ConditionNumber(); math.Abs(c-1) > EPSILON {
+		t.Errorf("condition number of a rotation is %v, want 1", c)
+	}
+	s := Ident
+	s.SetScaling(&vec3.T{1, 2, 4})
+	if c := s.ConditionNumber(); math.Abs(c-4) > EPSILON {
+		t.Errorf("condition number of scaling is %v, want 4", c)
+	}
+	nearlySingular := T{vec3.T{1, 0, 0}, vec3.T{0, 1, 0}, vec3.T{1, 1, 1e-9}}
+	if c := nearlySingular.ConditionNumber(); c < 1e8 {
+		t.Errorf("condition number of a nearly singular matrix is %v, want a large value", c)
+	}
+	if c := Zero.ConditionNumber(); !math.IsInf(c, 1) {
+		t.Errorf("condition number of the zero matrix is %v, want +Inf", c)
+	}
+}
+
+func TestSVD(t *testing.T) {
+	matrices := []T{
+		Ident,
+		{vec3.T{2, 0, 1}, vec3.T{1, 3, 0}, vec3.T{0, 1, 4}},
+		{vec3.T{1, 2, 3}, vec3.T{4, 5, 6}, vec3.T{7, 8, 9}},    // rank 2
+		{vec3.T{1, 2, 3}, vec3.T{2, 4, 6}, vec3.T{-1, -2, -3}}, // rank 1
+		{vec3.T{0, -1, 0}, vec3.T{1, 0, 0}, vec3.T{0, 0, -2}},
+		Zero,
+	}
+	for _, m := range matrices {
+		u, s, v := m.SVD()
+		if !(s[0] >= s[1] && s[1] >= s[2] && s[2] >= 0) {
+			t.Errorf("singular values %v of %v are not sorted in descending order", s, &m)
+		}
+		uT := u.Transposed()
+		var uTu T
+		uTu.AssignMul(&uT, &u)
+		vT := v.Transposed()
+		var vTv T
+		vTv.AssignMul(&vT, &v)
+		if !matEqual(&uTu, &Ident, EPSILON) || !matEqual(&vTv, &Ident, EPSILON) {
+			t.Errorf("singular vectors of %v are not orthonormal: u=%v v=%v", &m, &u, &v)
+		}
+		us := u
+		for i := range us {
+			us[i].Scale(s[i])
+		}
+		var reconstructed T
+		reconstructed.AssignMul(&us, &vT)
+		if !matEqual(&reconstructed, &m, EPSILON) {
+			t.Errorf("u * diag(s) * vT is %v, want %v", &reconstructed, &m)
+		}
+	}
+}
+
+func TestPseudoInverse(t *testing.T) {
+	m := T{vec3.T{2, 0, 1}, vec3.T{1, 3, 0}, vec3.T{0, 1, 4}}
+	inv := m.Inverted()
+	if pinv := m.PseudoInverse(EPSILON); !matEqual(&pinv, &inv, EPSILON) {
+		t.Errorf("pseudo-inverse of a full rank matrix is %v, want %v", &pinv, &inv)
+	}
+
+	// rank 2: projects onto the XY plane and scales
+	r := T{vec3.T{2, 0, 0}, vec3.T{0, 4, 0}, vec3.T{0, 0, 0}}
+	pinv := r.PseudoInverse(EPSILON)
+	want := T{vec3.T{0.5, 0, 0}, vec3.T{0, 0.25, 0}, vec3.T{0, 0, 0}}
+	if !matEqual(&pinv, &want, EPSILON) {
+		t.Errorf("pseudo-inverse of a rank 2 matrix is %v, want %v", &pinv, &want)
+	}
+	// least squares solution: the unreachable Z component is ignored
+	b := vec3.T{1, 2, 3}
+	x := pinv.MulVec3(&b)
+	if want := (vec3.T{0.5, 0.5, 0}); vec3.Distance(&x, &want) > EPSILON {
+		t.Errorf("least squares solution is %v, want %v", x, want)
+	}
+	// Moore-Penrose condition m * pinv * m = m
+	var mp, mpm T
+	mp.AssignMul(&r, &pinv)
+	mpm.AssignMul(&mp, &r)
+	if !matEqual(&mpm, &r, EPSILON) {
+		t.Errorf("m * pinv * m is %v, want %v", &mpm, &r)
+	}
+}
+
+func TestAxisAngle(t *testing.T) {
+	arbitrary := vec3.T{1, -2, 0.5}
+	arbitrary.Normalize()
+	for _, angle := range []float64{0.001, 0.5, math.Pi / 2, 2.5, math.Pi - 1e-4, math.Pi} {
+		for _, axis := range []vec3.T{vec3.UnitX, vec3.UnitZ, arbitrary} {
+			q := quaternion.FromAxisAngle(&axis, angle)
+			var m T
+			m.AssignQuaternion(&q)
+			gotAxis, gotAngle := m.AxisAngle()
+			if math.Abs(gotAngle-angle) > EPSILON {
+				t.Errorf("angle for %v around %v is %v", angle, axis, gotAngle)
+			}
+			if vec3.Distance(&gotAxis, &axis) > EPSILON {
+				// at exactly π the rotation around -axis is identical
+				neg := axis.Inverted()
+				if angle != math.Pi || vec3.Distance(&gotAxis, &neg) > EPSILON {
+					t.Errorf("axis for %v around %v is %v", angle, axis, gotAxis)
+				}
+			}
+		}
+	}
+
+	if axis, angle := Ident.AxisAngle(); angle != 0 || axis != vec3.UnitX {
+		t.Errorf("identity gives axis %v and angle %v", axis, angle)
+	}
+}
+
+func TestRotationBetweenFrames(t *testing.T) {
+	q := quaternion.FromEulerAngles(0.5, -0.8, 1.3)
+	var rot T
+	rot.AssignQuaternion(&q)
+	toX, toY, toZ := rot[0], rot[1], rot[2]
+
+	r := RotationBetweenFrames(&vec3.UnitX, &vec3.UnitY, &vec3.UnitZ, &toX, &toY, &toZ)
+	if !matEqual(&r, &rot, EPSILON) {
+		t.Errorf("rotation from the identity frame is %v, want %v", r, rot)
+	}
+
+	// between two rotated frames
+	q2 := quaternion.FromEulerAngles(-1.1, 0.2, 0.4)
+	var rot2 T
+	rot2.AssignQuaternion(&q2)
+	r = RotationBetweenFrames(&rot2[0], &rot2[1], &rot2[2], &toX, &toY, &toZ)
+	for i, want := range []vec3.T{toX, toY, toZ} {
+		if got := r.MulVec3(&rot2[i]); vec3.Distance(&got, &want) > EPSILON {
+			t.Errorf("basis vector %d maps to %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestAssignUnitQuaternion(t *testing.T) {
+	q := quaternion.FromEulerAngles(0.3, -1.1, 2.2)
+	var safe, unit T
+	safe.AssignQuaternion(&q)
+	unit.AssignUnitQuaternion(&q)
+	if !matEqual(&safe, &unit, EPSILON) {
+		t.Errorf("AssignUnitQuaternion is %v, want %v", unit, safe)
+	}
+
+	scaled := quaternion.T{q[0] * 3, q[1] * 3, q[2] * 3, q[3] * 3}
+	safe.AssignQuaternion(&scaled)
+	if !matEqual(&safe, &unit, EPSILON) {
+		t.Errorf("AssignQuaternion of a non unit quaternion is %v, want %v", safe, unit)
+	}
+}
+
+func BenchmarkAssignQuaternion(b *testing.B) {
+	q := quaternion.FromEulerAngles(0.3, -1.1, 2.2)
+	var m T
+	for i := 0; i < b.N; i++ {
+		m.AssignQuaternion(&q)
+	}
+}
+
+func BenchmarkAssignUnitQuaternion(b *testing.B) {
+	q := quaternion.FromEulerAngles(0.3, -1.1, 2.2)
+	var m T
+	for i := 0; i < b.N; i++ {
+		m.AssignUnitQuaternion(&q)
+	}
+}
+
+func Test2DTransforms(t *testing.T) {
+	translation := Translation2D(&vec2.T{3, -1})
+	rotation := Rotation2D(math.Pi / 2)
+	var m T
+	m.AssignMul(&translation, &rotation)
+
+	// rotate first, then translate
+	p := vec2.T{2, 1}
+	if got, want := m.TransformVec2(&p), (vec2.T{2, 1}); math.Abs(got[0]-want[0]) > EPSILON || math.Abs(got[1]-want[1]) > EPSILON {
+		t.Errorf("translate*rotate transforms %v to %v, want %v", p, got, want)
+	}
+
+	scaling := Scaling2D(&vec2.T{2, 3})
+	if got, want := scaling.TransformVec2(&p), (vec2.T{4, 3}); got != want {
+		t.Errorf("scaling transforms %v to %v, want %v", p, got, want)
+	}
+	if got, want := translation.TransformVec2(&p), (vec2.T{5, 0}); got != want {
+		t.Errorf("translation transforms %v to %v, want %v", p, got, want)
+	}
+}
+
+func TestScaleAlongAxis(t *testing.T) {
+	m := ScaleAlongAxis(&vec3.UnitX, 3)
+	if v := m.MulVec3(&vec3.T{1, 2, 3}); v != (vec3.T{3, 2, 3}) {
+		t.Errorf("scaling along UnitX gives %v, want [3 2 3]", v)
+	}
+
+	axis := vec3.T{1, 1, 0}
+	m = ScaleAlongAxis(&axis, 0.5)
+	if v := m.MulVec3(&axis); vec3.Distance(&v, &vec3.T{0.5, 0.5, 0}) > EPSILON {
+		t.Errorf("scaling the axis gives %v, want [0.5 0.5 0]", v)
+	}
+	for _, p := range []vec3.T{{1, -1, 0}, {0, 0, 2}, {2, -2, 5}} {
+		if v := m.MulVec3(&p); vec3.Distance(&v, &p) > EPSILON {
+			t.Errorf("perpendicular vector %v changed to %v", p, v)
+		}
+	}
+}
+
+func TestExpLogSO3(t *testing.T) {
+	axis := vec3.T{1, -2, 0.5}
+	axis.Normalize()
+	for _, angle := range []float64{0, 1e-9, 1e-5, 0.3, 2, math.Pi - 1e-4, math.Pi} {
+		w := axis.Scaled(angle)
+		m := ExpSO3(&w)
+
+		var want T
+		q := quaternion.FromAxisAngle(&axis, angle)
+		want.AssignQuaternion(&q)
+		if !matEqual(&m, &want, 1e-12) {
+			t.Errorf("ExpSO3(%v) is %v, want %v", w, m, want)
+		}
+
+		log := m.LogSO3()
+		if angle == math.Pi && vec3.Dot(&log, &w) < 0 {
+			// a rotation by π equals the rotation by π about the opposite axis
+			log.Invert()
+		}
+		if vec3.Distance(&log, &w) > 1e-9 {
+			t.Errorf("LogSO3 of rotation by %v returned %v, want %v", angle, log, w)
+		}
+	}
+}
+
+func TestTwistAngle(t *testing.T) {
+	axis := vec3.T{1, 2, -1}
+	axis.Normalize()
+	for _, angle := range []float64{0, 0.4, -1.3, 3} {
+		q := quaternion.FromAxisAngle(&axis, angle)
+		var m T
+		m.AssignQuaternion(&q)
+		if twist := m.TwistAngle(&axis); math.Abs(twist-angle) > EPSILON {
+			t.Errorf("twist of a rotation by %v about the axis is %v", angle, twist)
+		}
+	}
+
+	perpendicular := vec3.T{1, 0, 1}
+	perpendicular.Normalize()
+	q := quaternion.FromAxisAngle(&perpendicular, 1.1)
+	var m T
+	m.AssignQuaternion(&q)
+	if twist := m.TwistAngle(&axis); math.Abs(twist) > EPSILON {
+		t.Errorf("twist of a swing rotation is %v, want 0", twist)
+	}
+}
@@ -2,6 +2,7 @@
 package mat4
 
 import (
+	"errors"
 	"fmt"
 	"math"
 
@@ -24,9 +25,24 @@ var (
 		vec4.T{0, 0, 1, 0},
 		vec4.T{0, 0, 0, 1},
 	}
+
+	// Bias holds the matrix that maps normalized device coordinates
+	// from the range -1 to 1 to texture coordinates in the range 0 to 1
+	// by scaling with 0.5 and then translating by 0.5.
+	// See ShadowMatrix.
+	Bias = T{
+		vec4.T{0.5, 0, 0, 0},
+		vec4.T{0, 0.5, 0, 0},
+		vec4.T{0, 0, 0.5, 0},
+		vec4.T{0.5, 0.5, 0.5, 1},
+	}
 )
 
-// T represents a 4x4 matrix.
+// T represents a 4x4 matrix as 4 column vectors.
+// The storage is column-major: mat[col][row] addresses the element
+// in column col and row row, so the translation is held in mat[3][0:3].
+// Use AsRowMajor, AsColumnMajor, FromRowMajor and FromColumnMajor
+// to exchange matrices with APIs that use flat arrays.
 type T [4]vec4.T
 
 // From copies a T from a generic.T implementation.
@@ -61,6 +77,68 @@ func (mat *T) String() string {
 	return fmt.Sprintf("%s %s %s %s", mat[0].String(), mat[1].String(), mat[2].String(), mat[3].String())
 }
 
+// FromColumnMajor returns a matrix from 16 values where
+// every group of 4 consecutive values is one column, as expected by OpenGL.
+func FromColumnMajor(data [16]float64) T {
+	var mat T
+	for col := range mat {
+		for row := range mat[col] {
+			mat[col][row] = data[col*4+row]
+		}
+	}
+	return mat
+}
+
+// FromRowMajor returns a matrix from 16 values where
+// every group of 4 consecutive values is one row, as expected by DirectX.
+func FromRowMajor(data [16]float64) T {
+	var mat T
+	for col := range mat {
+		for row := range mat[col] {
+			mat[col][row] = data[row*4+col]
+		}
+	}
+	return mat
+}
+
+// FromSlice returns a matrix from the 16 values of data,
+// which are interpreted as in FromColumnMajor if columnMajor is true
+// and as in FromRowMajor otherwise.
+// An error is returned if data does not hold exactly 16 values.
+func FromSlice(data []float64, columnMajor bool) (T, error) {
+	if len(data) != 16 {
+		return T{}, errors.New("mat4.FromSlice: data must hold 16 values")
+	}
+	var array [16]float64
+	copy(array[:], data)
+	if columnMajor {
+		return FromColumnMajor(array), nil
+	}
+	return FromRowMajor(array), nil
+}
+
+// AsColumnMajor returns the elements of the matrix column by column.
+func (mat *T) AsColumnMajor() [16]float64 {
+	var data [16]float64
+	for col := range mat {
+		for row := range mat[col] {
+			data[col*4+row] = mat[col][row]
+		}
+	}
+	return data
+}
+
+// AsRowMajor returns the elements of the matrix row by row.
+func (mat *T) AsRowMajor() [16]float64 {
+	var data [16]float64
+	for col := range mat {
+		for row := range mat[col] {
+			data[row*4+col] = mat[col][row]
+		}
+	}
+	return data
+}
+
 // Rows returns the number of rows of the matrix.
 func (mat *T) Rows() int {
 	return 4
@@ -105,6 +183,17 @@ func (mat *T) Scaled(f float64) T {
 	return *r.Scale(f)
 }
 
+// AddScaled adds b multiplied by weight element wise to mat and returns mat.
+// Accumulating weighted bone matrices this way is the core step of linear blend skinning.
+func (mat *T) AddScaled(b *T, weight float64) *T {
+	for col := range mat {
+		for row := range mat[col] {
+			mat[col][row] += b[col][row] * weight
+		}
+	}
+	return mat
+}
+
 // Trace returns the trace value for the matrix.
 func (mat *T) Trace() float64 {
 	return mat[0][0] + mat[1][1] + mat[2][2] + mat[3][3]
@@ -285,7 +374,51 @@ func (mat *T) Quaternion() quaternion.T {
 }
 
 // AssignQuaternion assigns a quaternion to the rotations part of the matrix and sets the other elements to their ident value.
+// q is normalized, a zero quaternion results in the ident rotation.
+// See AssignUnitQuaternion for a faster version for unit quaternions.
 func (mat *T) AssignQuaternion(q *quaternion.T) *T {
+	// scaling by 2/|q|² is the same as normalizing q first
+	var s float64
+	if norm := q.Norm(); norm != 0 {
+		s = 2 / norm
+	}
+	xx := q[0] * q[0] * s
+	yy := q[1] * q[1] * s
+	zz := q[2] * q[2] * s
+	xy := q[0] * q[1] * s
+	xz := q[0] * q[2] * s
+	yz := q[1] * q[2] * s
+	wx := q[3] * q[0] * s
+	wy := q[3] * q[1] * s
+	wz := q[3] * q[2] * s
+
+	mat[0][0] = 1 - (yy + zz)
+	mat[1][0] = xy - wz
+	mat[2][0] = xz + wy
+	mat[3][0] = 0
+
+	mat[0][1] = xy + wz
+	mat[1][1] = 1 - (xx + zz)
+	mat[2][1] = yz - wx
+	mat[3][1] = 0
+
+	mat[0][2] = xz - wy
+	mat[1][2] = yz + wx
+	mat[2][2] = 1 - (xx + yy)
+	mat[3][2] = 0
+
+	mat[0][3] = 0
+	mat[1][3] = 0
+	mat[2][3] = 0
+	mat[3][3] = 1
+
+	return mat
+}
+
+// AssignUnitQuaternion assigns the unit quaternion q to the rotations part of the matrix and sets the other elements to their ident value.
+// It skips the normalization of AssignQuaternion and must only be used
+// with quaternions of unit length, other quaternions result in a scaled and sheared matrix.
+func (mat *T) AssignUnitQuaternion(q *quaternion.T) *T {
 	xx := q[0] * q[0] * 2
 	yy := q[1] * q[1] * 2
 	zz := q[2] * q[2] * 2
@@ -541,6 +674,41 @@ func (mat *T) Determinant3x3() float64 {
 		mat[0][0]*mat[2][1]*mat[1][2]
 }
 
+// Determinant returns the determinant of the matrix
+// by cofactor expansion along the first row.
+func (mat *T) Determinant() float64 {
+	s1 := mat[0][0]
+	det1 := mat[1][1]*mat[2][2]*mat[3][3] +
+		mat[2][1]*mat[3][2]*mat[1][3] +
+		mat[3][1]*mat[1][2]*mat[2][3] -
+		mat[3][1]*mat[2][2]*mat[1][3] -
+		mat[2][1]*mat[1][2]*mat[3][3] -
+		mat[1][1]*mat[3][2]*mat[2][3]
+
+	s2 := mat[0][1]
+	det2 := mat[1][0]*mat[2][2]*mat[3][3] +
+		mat[2][0]*mat[3][2]*mat[1][3] +
+		mat[3][0]*mat[1][2]*mat[2][3] -
+		mat[3][0]*mat[2][2]*mat[1][3] -
+		mat[2][0]*mat[1][2]*mat[3][3] -
+		mat[1][0]*mat[3][2]*mat[2][3]
+	s3 := mat[0][2]
+	det3 := mat[1][0]*mat[2][1]*mat[3][3] +
+		mat[2][0]*mat[3][1]*mat[1][3] +
+		mat[3][0]*mat[1][1]*mat[2][3] -
+		mat[3][0]*mat[2][1]*mat[1][3] -
+		mat[2][0]*mat[1][1]*mat[3][3] -
+		mat[1][0]*mat[3][1]*mat[2][3]
+	s4 := mat[0][3]
+	det4 := mat[1][0]*mat[2][1]*mat[3][2] +
+		mat[2][0]*mat[3][1]*mat[1][2] +
+		mat[3][0]*mat[1][1]*mat[2][2] -
+		mat[3][0]*mat[2][1]*mat[1][2] -
+		mat[2][0]*mat[1][1]*mat[3][2] -
+		mat[1][0]*mat[3][1]*mat[2][2]
+	return s1*det1 - s2*det2 + s3*det3 - s4*det4
+}
+
 // IsReflective returns true if the matrix can be reflected by a plane.
 func (mat *T) IsReflective() bool {
 	return mat.Determinant3x3() < 0
@@ -558,6 +726,13 @@ func (mat *T) Transpose() *T {
 	return mat.Transpose3x3()
 }
 
+// Transposed returns a transposed copy of the matrix.
+func (mat *T) Transposed() T {
+	result := *mat
+	result.Transpose()
+	return result
+}
+
 // Transpose3x3 transposes the 3x3 sub-matrix.
 func (mat *T) Transpose3x3() *T {
 	swap(&mat[1][0], &mat[0][1])
@@ -565,3 +740,767 @@ func (mat *T) Transpose3x3() *T {
 	swap(&mat[2][1], &mat[1][2])
 	return mat
 }
+
+// Adjugate computes the adjugate of this matrix and returns mat.
+func (mat *T) Adjugate() *T {
+	matOrig := *mat
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			// - 1 for odd i+j, 1 for even i+j
+			sign := float64(((i+j)%2)*-2 + 1)
+			mat[i][j] = matOrig.maskedBlock(i, j).Determinant() * sign
+		}
+	}
+	return mat.Transpose()
+}
+
+// Adjugated returns an adjugated copy of the matrix.
+func (mat *T) Adjugated() T {
+	result := *mat
+	result.Adjugate()
+	return result
+}
+
+// maskedBlock returns the 3x3 matrix without the column blockI and the row blockJ.
+func (mat *T) maskedBlock(blockI, blockJ int) *mat3.T {
+	var m mat3.T
+	mi := 0
+	for i := 0; i < 4; i++ {
+		if i == blockI {
+			continue
+		}
+		mj := 0
+		for j := 0; j < 4; j++ {
+			if j == blockJ {
+				continue
+			}
+			m[mi][mj] = mat[i][j]
+			mj++
+		}
+		mi++
+	}
+	return &m
+}
+
+// Invert inverts the matrix and returns mat.
+// Does not check if matrix is singular and may lead to strange results!
+func (mat *T) Invert() *T {
+	ooDet := 1 / mat.Determinant()
+	mat.Adjugate()
+	for i := range mat {
+		for j := range mat[i] {
+			mat[i][j] *= ooDet
+		}
+	}
+	return mat
+}
+
+// Inverted returns an inverted copy of the matrix.
+// Does not check if matrix is singular and may lead to strange results!
+func (mat *T) Inverted() T {
+	result := *mat
+	result.Invert()
+	return result
+}
+
+// NormalMatrix3 returns the inverse transpose of the 3x3 sub-matrix.
+// Normals must be transformed with this matrix instead of the matrix itself
+// to stay perpendicular to the surface under non-uniform scaling and shearing.
+// The transformed normals are not normalized.
+// Does not check if the 3x3 sub-matrix is singular and may lead to strange results!
+func (mat *T) NormalMatrix3() mat3.T {
+	m := mat3.T{
+		vec3.T{mat[0][0], mat[0][1], mat[0][2]},
+		vec3.T{mat[1][0], mat[1][1], mat[1][2]},
+		vec3.T{mat[2][0], mat[2][1], mat[2][2]},
+	}
+	m.Invert()
+	return m.Transposed()
+}
+
+// Pow returns the matrix raised to the integer power n using exponentiation by squaring.
+// Pow(0) returns the identity matrix, negative powers are computed from the inverse.
+// An error is returned for negative powers of a singular matrix.
+func (mat *T) Pow(n int) (T, error) {
+	base := *mat
+	if n < 0 {
+		if math.Abs(mat.Determinant()) < 1e-300 {
+			return Zero, errors.New("mat4.Pow: negative power of singular matrix")
+		}
+		base.Invert()
+		n = -n
+	}
+	result := Ident
+	for n > 0 {
+		if n&1 != 0 {
+			prod := result
+			result.AssignMul(&prod, &base)
+		}
+		n >>= 1
+		if n > 0 {
+			sq := base
+			base.AssignMul(&sq, &sq)
+		}
+	}
+	return result, nil
+}
+
+// Jitter returns a copy of the projection matrix with a clip-space translation
+// that shifts every projected point by offsetX and offsetY in normalized device coordinates.
+// Positive offsets move points to the right (+X) and up (+Y) in NDC.
+// To shift by a subpixel amount of a viewport with width x height pixels,
+// pass offsetX = 2*pixelsX/width and offsetY = 2*pixelsY/height.
+func (mat *T) Jitter(offsetX, offsetY float64) T {
+	result := *mat
+	for col := range result {
+		result[col][0] += offsetX * result[col][3]
+		result[col][1] += offsetY * result[col][3]
+	}
+	return result
+}
+
+// ExtractScale returns the scale per axis as the lengths of the first three basis column vectors.
+// If the 3x3 sub-matrix has a negative determinant (the transformation contains a mirroring),
+// the X scale is negated so that dividing the basis vectors by the scale
+// always leaves a proper rotation without reflection.
+func (mat *T) ExtractScale() vec3.T {
+	x := mat[0].Vec3()
+	y := mat[1].Vec3()
+	z := mat[2].Vec3()
+	scale := vec3.T{x.Length(), y.Length(), z.Length()}
+	if mat.Determinant3x3() < 0 {
+		scale[0] = -scale[0]
+	}
+	return scale
+}
+
+// ProjectPoints transforms every point of src by the model-view-projection matrix mvp,
+// performs the perspective divide and writes the resulting normalized device coordinates to dst.
+// dst must be at least as long as src and may be the same slice.
+// The returned clipMask is true for every point inside the clip volume (-w <= x, y, z <= w).
+// Points with w <= 0 are behind the viewer: they are never inside,
+// and dst receives their clip-space x, y, z without the perspective divide.
+func ProjectPoints(dst []vec3.T, src []vec3.T, mvp *T) (clipMask []bool) {
+	clipMask = make([]bool, len(src))
+	for i := range src {
+		v := vec4.FromVec3(&src[i])
+		mvp.TransformVec4(&v)
+		w := v[3]
+		if w <= 0 {
+			dst[i] = v.Vec3()
+			continue
+		}
+		clipMask[i] = -w <= v[0] && v[0] <= w &&
+			-w <= v[1] && v[1] <= w &&
+			-w <= v[2] && v[2] <= w
+		dst[i] = v.Vec3DividedByW()
+	}
+	return clipMask
+}
+
+// Reflection returns the matrix that reflects points across a plane.
+// The plane is given by the coefficients (a, b, c, d) of the plane equation
+// a*x + b*y + c*z + d = 0 and must be normalized so that (a, b, c) has unit length.
+func Reflection(plane *vec4.T) T {
+	result := Ident
+	for col := 0; col < 3; col++ {
+		for row := 0; row < 3; row++ {
+			result[col][row] -= 2 * plane[row] * plane[col]
+		}
+	}
+	for row := 0; row < 3; row++ {
+		result[3][row] = -2 * plane[3] * plane[row]
+	}
+	return result
+}
+
+// Perspective returns a right-handed perspective projection with the vertical field of view fovyRadians,
+// the aspect ratio width/height and the near and far plane distances, like gluPerspective.
+func Perspective(fovyRadians, aspect, near, far float64) T {
+	top := near * math.Tan(fovyRadians*0.5)
+	right := top * aspect
+	return PerspectiveOffCenter(-right, right, -top, top, near, far)
+}
+
+// PerspectiveZoom returns the perspective projection of Perspective
+// with the vertical field of view baseFovyRadians/zoom.
+// A zoom above 1 narrows the field of view and magnifies the image,
+// a zoom of 2 halves the visible angle.
+func PerspectiveZoom(baseFovyRadians, zoom, aspect, near, far float64) T {
+	return Perspective(baseFovyRadians/zoom, aspect, near, far)
+}
+
+// TwoPointPerspective returns a perspective projection like Perspective with
+// a vertically shifted frustum, like the shift lens of an architectural camera.
+// Combined with a level view matrix (no pitch) vertical lines stay vertical
+// on screen while the visible region moves up or down.
+// verticalShift is measured in half image heights, a positive shift moves
+// the view up and the horizon down to NDC Y = -verticalShift.
+func TwoPointPerspective(fovyRadians, aspect, near, far, verticalShift float64) T {
+	top := near * math.Tan(fovyRadians*0.5)
+	right := top * aspect
+	shift := top * verticalShift
+	return PerspectiveOffCenter(-right, right, -top+shift, top+shift, near, far)
+}
+
+// PerspectiveInfinite returns a perspective projection with the vertical field of view fovyRadians,
+// the aspect ratio width/height and the near plane distance near, whose far plane is at infinity.
+// It is the limit of the perspective projection for zfar towards infinity:
+// points at any distance in front of the near plane map to depths below 1 and are never far-clipped.
+func PerspectiveInfinite(fovyRadians, aspect, near float64) T {
+	f := 1 / math.Tan(fovyRadians*0.5)
+	return T{
+		vec4.T{f / aspect, 0, 0, 0},
+		vec4.T{0, f, 0, 0},
+		vec4.T{0, 0, -1, -1},
+		vec4.T{0, 0, -2 * near, 0},
+	}
+}
+
+// PerspectiveOffCenter returns an asymmetric perspective projection
+// for the view frustum with the near plane rectangle from (left, bottom) to (right, top)
+// at the distance near and the far plane at the distance far, like glFrustum.
+// See also AssignPerspectiveProjection
+func PerspectiveOffCenter(left, right, bottom, top, near, far float64) T {
+	var mat T
+	mat.AssignPerspectiveProjection(left, right, bottom, top, near, far)
+	return mat
+}
+
+// PerspectiveFromTangents returns an asymmetric perspective projection
+// from the tangents of the half angles between the view direction and the
+// left, right, upper and lower frustum planes, as provided per eye by VR SDKs.
+// All tangents are positive for a frustum that contains the view direction.
+func PerspectiveFromTangents(tanLeft, tanRight, tanUp, tanDown, near, far float64) T {
+	return PerspectiveOffCenter(-tanLeft*near, tanRight*near, -tanDown*near, tanUp*near, near, far)
+}
+
+// PerspectiveParams extracts the parameters of a symmetric perspective projection
+// as created by Perspective. far is +Inf for matrices created by PerspectiveInfinite.
+// ok is false if the matrix is not a symmetric perspective projection.
+func (mat *T) PerspectiveParams() (fovy, aspect, near, far float64, ok bool) {
+	if mat[2][3] != -1 || mat[3][3] != 0 || mat[0][3] != 0 || mat[1][3] != 0 ||
+		mat[1][0] != 0 || mat[2][0] != 0 || mat[3][0] != 0 ||
+		mat[0][1] != 0 || mat[2][1] != 0 || mat[3][1] != 0 ||
+		mat[0][2] != 0 || mat[1][2] != 0 ||
+		mat[0][0] <= 0 || mat[1][1] <= 0 {
+		return 0, 0, 0, 0, false
+	}
+	a, b := mat[2][2], mat[3][2]
+	fovy = 2 * math.Atan(1/mat[1][1])
+	aspect = mat[1][1] / mat[0][0]
+	near = b / (a - 1)
+	if a == -1 {
+		far = math.Inf(1)
+	} else {
+		far = b / (a + 1)
+	}
+	if near <= 0 || far <= near {
+		return 0, 0, 0, 0, false
+	}
+	return fovy, aspect, near, far, true
+}
+
+// ShadowMatrix returns Bias * lightViewProj, which transforms world space points
+// directly into the texture space of a shadow map rendered with lightViewProj.
+func ShadowMatrix(lightViewProj *T) T {
+	var result T
+	result.AssignMul(&Bias, lightViewProj)
+	return result
+}
+
+// Reprojection returns previousViewProj * inverse(currentViewProj), which transforms
+// clip space coordinates of the current frame into the clip space of the previous frame
+// for static geometry, as used by temporal anti-aliasing and motion blur.
+// An error is returned if currentViewProj is singular.
+func Reprojection(currentViewProj, previousViewProj *T) (T, error) {
+	if math.Abs(currentViewProj.Determinant()) < 1e-300 {
+		return T{}, errors.New("mat4.Reprojection: current view projection matrix is singular")
+	}
+	inv := currentViewProj.Inverted()
+	var result T
+	result.AssignMul(previousViewProj, &inv)
+	return result, nil
+}
+
+// CascadeSplits returns the cascades+1 view distances from near to far that split
+// the view frustum into the depth ranges of cascaded shadow maps.
+// The splits blend between logarithmic and uniform spacing with
+// lambda*near*(far/near)^(i/cascades) + (1-lambda)*(near+(far-near)*i/cascades),
+// so lambda 0 results in uniform and lambda 1 in logarithmic splits.
+// near must be greater than zero for lambda != 0.
+func CascadeSplits(near, far float64, cascades int, lambda float64) []float64 {
+	if cascades <= 0 {
+		return nil
+	}
+	splits := make([]float64, cascades+1)
+	for i := range splits {
+		f := float64(i) / float64(cascades)
+		splits[i] = (1 - lambda) * (near + (far-near)*f)
+		if lambda != 0 {
+			splits[i] += lambda * near * math.Pow(far/near, f)
+		}
+	}
+	splits[cascades] = far
+	return splits
+}
+
+// ViewFromForwardUp returns a right-handed view matrix for a camera at position
+// looking into the direction forward, with up giving the approximate up direction.
+// As in OpenGL the camera looks down the negative Z axis in view space,
+// X points to the right and Y points up.
+// If forward and up are parallel, a perpendicular up direction is chosen automatically.
+func ViewFromForwardUp(position, forward, up *vec3.T) T {
+	f := forward.Normalized()
+	s := vec3.Cross(&f, up)
+	if s.LengthSqr() < 1e-12*up.LengthSqr() || up.IsZero() {
+		alt := vec3.UnitY
+		if math.Abs(f[1]) > 0.9 {
+			alt = vec3.UnitZ
+		}
+		s = vec3.Cross(&f, &alt)
+	}
+	s.Normalize()
+	u := vec3.Cross(&s, &f)
+	return T{
+		vec4.T{s[0], u[0], -f[0], 0},
+		vec4.T{s[1], u[1], -f[1], 0},
+		vec4.T{s[2], u[2], -f[2], 0},
+		vec4.T{-vec3.Dot(&s, position), -vec3.Dot(&u, position), vec3.Dot(&f, position), 1},
+	}
+}
+
+// LookAt returns a right-handed view matrix for a camera at eye looking at center,
+// like gluLookAt. The camera looks down the negative Z axis in view space,
+// so points in front of the camera have negative view space Z coordinates.
+// See also LookAtLH and ViewFromForwardUp
+func LookAt(eye, center, up *vec3.T) T {
+	forward := vec3.Sub(center, eye)
+	return ViewFromForwardUp(eye, &forward, up)
+}
+
+// LookAtLH returns a left-handed view matrix for a camera at eye looking at center,
+// as used by DirectX. The camera looks down the positive Z axis in view space,
+// so points in front of the camera have positive view space Z coordinates.
+// X points to the right and Y points up.
+// If the view direction and up are parallel, a perpendicular up direction is chosen automatically.
+func LookAtLH(eye, center, up *vec3.T) T {
+	z := vec3.Sub(center, eye)
+	z.Normalize()
+	x := vec3.Cross(up, &z)
+	if x.LengthSqr() < 1e-12*up.LengthSqr() || up.IsZero() {
+		alt := vec3.UnitY
+		if math.Abs(z[1]) > 0.9 {
+			alt = vec3.UnitZ
+		}
+		x = vec3.Cross(&alt, &z)
+	}
+	x.Normalize()
+	y := vec3.Cross(&z, &x)
+	return T{
+		vec4.T{x[0], y[0], z[0], 0},
+		vec4.T{x[1], y[1], z[1], 0},
+		vec4.T{x[2], y[2], z[2], 0},
+		vec4.T{-vec3.Dot(&x, eye), -vec3.Dot(&y, eye), -vec3.Dot(&z, eye), 1},
+	}
+}
+
+// DecomposeFull decomposes an affine transformation with shear into
+// mat = Translate(t) * Rotate(r) * Shear(shear) * Scale(scale)
+// following the unmatrix algorithm from Graphics Gems II.
+// Shear is the upper triangular matrix with ones on the diagonal and the
+// shear factors xy = shear[0], xz = shear[1] and yz = shear[2] above it,
+// so that the Y basis vector is sheared along X and the Z basis vector along X and Y.
+// If the transformation contains a reflection, all scale factors are negative.
+// ok is false if the matrix has a projective part or a zero scale.
+func (mat *T) DecomposeFull() (t vec3.T, r quaternion.T, shear vec3.T, scale vec3.T, ok bool) {
+	if mat[0][3] != 0 || mat[1][3] != 0 || mat[2][3] != 0 || mat[3][3] == 0 {
+		return t, quaternion.Ident, shear, scale, false
+	}
+	oow := 1 / mat[3][3]
+	t = mat[3].Vec3()
+	t.Scale(oow)
+	c0 := mat[0].Vec3()
+	c1 := mat[1].Vec3()
+	c2 := mat[2].Vec3()
+	c0.Scale(oow)
+	c1.Scale(oow)
+	c2.Scale(oow)
+
+	scale[0] = c0.Length()
+	if scale[0] == 0 {
+		return t, quaternion.Ident, shear, scale, false
+	}
+	c0.Scale(1 / scale[0])
+
+	shear[0] = vec3.Dot(&c0, &c1)
+	d := c0.Scaled(shear[0])
+	c1.Sub(&d)
+	scale[1] = c1.Length()
+	if scale[1] == 0 {
+		return t, quaternion.Ident, shear, scale, false
+	}
+	c1.Scale(1 / scale[1])
+	shear[0] /= scale[1]
+
+	shear[1] = vec3.Dot(&c0, &c2)
+	d = c0.Scaled(shear[1])
+	c2.Sub(&d)
+	shear[2] = vec3.Dot(&c1, &c2)
+	d = c1.Scaled(shear[2])
+	c2.Sub(&d)
+	scale[2] = c2.Length()
+	if scale[2] == 0 {
+		return t, quaternion.Ident, shear, scale, false
+	}
+	c2.Scale(1 / scale[2])
+	shear[1] /= scale[2]
+	shear[2] /= scale[2]
+
+	cross := vec3.Cross(&c1, &c2)
+	if vec3.Dot(&c0, &cross) < 0 {
+		scale.Invert()
+		c0.Invert()
+		c1.Invert()
+		c2.Invert()
+	}
+	r = quaternion.FromBasis(&c0, &c1, &c2)
+	return t, r, shear, scale, true
+}
+
+// ValidateDecomposition decomposes mat with DecomposeFull, recomposes the translation,
+// rotation and scale without the shear and returns if the result matches mat
+// within epsilon for every element.
+// It is true for clean affine TRS transformations and false for matrices
+// with shear, a projective part or a zero scale.
+func (mat *T) ValidateDecomposition(epsilon float64) bool {
+	t, r, _, scale, ok := mat.DecomposeFull()
+	if !ok {
+		return false
+	}
+	var recomposed T
+	recomposed.AssignQuaternion(&r)
+	for i := 0; i < 3; i++ {
+		recomposed[i].Scale(scale[i])
+	}
+	recomposed[3] = vec4.T{t[0], t[1], t[2], 1}
+	w := mat[3][3]
+	for col := range recomposed {
+		for row := range recomposed[col] {
+			if math.Abs(recomposed[col][row]*w-mat[col][row]) > epsilon {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// SnapTransform returns the transformation m with its translation snapped
+// to multiples of posGrid and the Euler angles of its rotation (see ExtractEulerAngles)
+// snapped to multiples of angleStepRadians.
+// The scale is kept, shear is discarded.
+// A posGrid or angleStepRadians <= 0 disables the respective snapping.
+// m is returned unchanged if DecomposeFull fails.
+func SnapTransform(m *T, posGrid float64, angleStepRadians float64) T {
+	t, r, _, scale, ok := m.DecomposeFull()
+	if !ok {
+		return *m
+	}
+	snap := func(v, step float64) float64 {
+		if step <= 0 {
+			return v
+		}
+		return math.Round(v/step) * step
+	}
+	var result T
+	result.AssignQuaternion(&r)
+	yHead, xPitch, zRoll := result.ExtractEulerAngles()
+	result.AssignEulerRotation(
+		snap(yHead, angleStepRadians),
+		snap(xPitch, angleStepRadians),
+		snap(zRoll, angleStepRadians),
+	)
+	for i := 0; i < 3; i++ {
+		result[i].Scale(scale[i])
+	}
+	result[3] = vec4.T{snap(t[0], posGrid), snap(t[1], posGrid), snap(t[2], posGrid), 1}
+	return result
+}
+
+// ScrewInterpolate interpolates between the rigid transformations a and b at t (0,1)
+// along the screw motion that takes a to b: a rotation about an axis combined
+// with a translation along the same axis, both proportional to t.
+// This is the geodesic between a and b in the space of rigid transformations,
+// so every point moves along a helix at constant speed.
+// a and b must only consist of rotation and translation.
+func ScrewInterpolate(a, b *T, t float64) T {
+	inv := a.Inverted()
+	var delta T
+	delta.AssignMul(b, &inv)
+
+	x, y, z := delta[0].Vec3(), delta[1].Vec3(), delta[2].Vec3()
+	q := quaternion.FromBasis(&x, &y, &z)
+	if q[3] < 0 {
+		q.Negate()
+	}
+	d := delta[3].Vec3()
+
+	var step T
+	sinHalf := math.Sqrt(q[0]*q[0] + q[1]*q[1] + q[2]*q[2])
+	if sinHalf < 1e-9 {
+		step = Ident
+		step.SetTranslation(&vec3.T{d[0] * t, d[1] * t, d[2] * t})
+	} else {
+		angle := 2 * math.Atan2(sinHalf, q[3])
+		axis := vec3.T{q[0] / sinHalf, q[1] / sinHalf, q[2] / sinHalf}
+		// split the translation into the part along the axis
+		// and a rotation about the point c on the axis
+		along := axis.Scaled(vec3.Dot(&d, &axis))
+		perp := vec3.Sub(&d, &along)
+		cross := vec3.Cross(&axis, &perp)
+		cross.Scale(1 / math.Tan(angle*0.5))
+		c := vec3.Add(&perp, &cross)
+		c.Scale(0.5)
+
+		rotation := quaternion.FromAxisAngle(&axis, angle*t)
+		step.AssignUnitQuaternion(&rotation)
+		rc := step.MulVec3W(&c, 0)
+		step.SetTranslation(&vec3.T{
+			c[0] - rc[0] + along[0]*t,
+			c[1] - rc[1] + along[1]*t,
+			c[2] - rc[2] + along[2]*t,
+		})
+	}
+	var result T
+	result.AssignMul(&step, a)
+	return result
+}
+
+// ApplyAdditive applies the additive animation delta with weight to the transformation base
+// and returns Translate(tb + weight*td) * Rotate(slerp(ident, rd, weight) * rb) * Scale(sb * lerp(1, sd, weight))
+// where t, r and s are the translation, rotation and scale decomposed with DecomposeFull.
+// A weight of 0 returns the base without shear, a weight of 1 applies the full delta.
+// base is returned unchanged if one of the matrices can't be decomposed.
+func ApplyAdditive(base *T, delta *T, weight float64) T {
+	tb, rb, _, sb, ok := base.DecomposeFull()
+	if !ok {
+		return *base
+	}
+	td, rd, _, sd, ok := delta.DecomposeFull()
+	if !ok {
+		return *base
+	}
+	slerp := quaternion.PrepareSlerp(&quaternion.Ident, &rd)
+	rw := slerp.At(weight)
+	r := quaternion.Mul(&rw, &rb)
+
+	var result T
+	result.AssignQuaternion(&r)
+	for i := 0; i < 3; i++ {
+		result[i].Scale(sb[i] * (1 + (sd[i]-1)*weight))
+	}
+	result[3] = vec4.T{tb[0] + td[0]*weight, tb[1] + td[1]*weight, tb[2] + td[2]*weight, 1}
+	return result
+}
+
+// LocalToWorld returns the matrix that transforms coordinates of the local frame
+// with the given origin and the orthonormal axes x, y and z into world coordinates.
+// It is the inverse of WorldToLocal.
+func LocalToWorld(origin *vec3.T, x, y, z *vec3.T) T {
+	return T{
+		vec4.T{x[0], x[1], x[2], 0},
+		vec4.T{y[0], y[1], y[2], 0},
+		vec4.T{z[0], z[1], z[2], 0},
+		vec4.T{origin[0], origin[1], origin[2], 1},
+	}
+}
+
+// WorldToLocal returns the matrix that transforms world coordinates into the local frame
+// with the given origin and the orthonormal axes x, y and z.
+// Because the axes are orthonormal, the rotational part is the transpose
+// of the one of LocalToWorld.
+func WorldToLocal(origin *vec3.T, x, y, z *vec3.T) T {
+	return T{
+		vec4.T{x[0], y[0], z[0], 0},
+		vec4.T{x[1], y[1], z[1], 0},
+		vec4.T{x[2], y[2], z[2], 0},
+		vec4.T{-vec3.Dot(x, origin), -vec3.Dot(y, origin), -vec3.Dot(z, origin), 1},
+	}
+}
+
+// ImpostorMatrix returns the model matrix of an impostor quad at position
+// that faces the camera at cameraPos.
+// The quad is expected in the local XY plane from -0.5 to 0.5 with its front
+// facing the local positive Z axis; it is scaled to width along the local X
+// and to height along the local Y axis, which is aligned with cameraUp
+// projected onto the quad plane.
+// If the view direction and cameraUp are parallel, a perpendicular up direction is chosen automatically.
+func ImpostorMatrix(position, cameraPos, cameraUp *vec3.T, width, height float64) T {
+	z := vec3.Sub(cameraPos, position)
+	z.Normalize()
+	x := vec3.Cross(cameraUp, &z)
+	if x.LengthSqr() < 1e-12*cameraUp.LengthSqr() || cameraUp.IsZero() {
+		alt := vec3.UnitY
+		if math.Abs(z[1]) > 0.9 {
+			alt = vec3.UnitZ
+		}
+		x = vec3.Cross(&alt, &z)
+	}
+	x.Normalize()
+	y := vec3.Cross(&z, &x)
+	x.Scale(width)
+	y.Scale(height)
+	return LocalToWorld(position, &x, &y, &z)
+}
+
+// FrustumCorners returns the 8 world space corners of the view frustum
+// by unprojecting the corners of the NDC cube from -1 to 1
+// with the inverse view-projection matrix invViewProj.
+// Bit 0 of the corner index selects right (set) or left, bit 1 top or bottom
+// and bit 2 the far or the near plane, so the corners 0 to 3 are the near plane
+// corners left-bottom, right-bottom, left-top, right-top followed by the far plane
+// corners in the same order.
+func FrustumCorners(invViewProj *T) [8]vec3.T {
+	var corners [8]vec3.T
+	for i := range corners {
+		ndc := vec4.T{-1, -1, -1, 1}
+		if i&1 != 0 {
+			ndc[0] = 1
+		}
+		if i&2 != 0 {
+			ndc[1] = 1
+		}
+		if i&4 != 0 {
+			ndc[2] = 1
+		}
+		world := invViewProj.MulVec4(&ndc)
+		corners[i] = world.Vec3DividedByW()
+	}
+	return corners
+}
+
+// SkinMatrix returns the skinning matrix worldBone * inverseBindPose of a bone
+// that transforms vertices from the bind pose of the mesh
+// to their current world position under the bone.
+func SkinMatrix(worldBone, inverseBindPose *T) T {
+	var result T
+	result.AssignMul(worldBone, inverseBindPose)
+	return result
+}
+
+// SkinMatrices writes the SkinMatrix of world[i] and inverseBind[i] to dst[i]
+// for all bones. inverseBind and dst must be at least as long as world.
+func SkinMatrices(dst, world, inverseBind []T) {
+	for i := range world {
+		dst[i].AssignMul(&world[i], &inverseBind[i])
+	}
+}
+
+// ClipSpace is the range of the normalized device Z coordinate
+// a projection matrix maps the near and far planes to.
+type ClipSpace int
+
+const (
+	// ClipSpaceUnknown is returned by ClipSpaceConvention if the convention can't be determined.
+	ClipSpaceUnknown ClipSpace = iota
+	// ClipSpaceNegativeOneToOne maps the near plane to -1 and the far plane to 1 as in OpenGL.
+	// All projections of this package except the ZO variants use it.
+	ClipSpaceNegativeOneToOne
+	// ClipSpaceZeroToOne maps the near plane to 0 and the far plane to 1 as in Direct3D, Vulkan and Metal.
+	ClipSpaceZeroToOne
+)
+
+// PerspectiveZO returns the same perspective projection as Perspective,
+// but maps the near plane to a normalized device Z of 0 instead of -1.
+func PerspectiveZO(fovyRadians, aspect, near, far float64) T {
+	f := 1 / math.Tan(fovyRadians*0.5)
+	ooNearFar := 1 / (near - far)
+	return T{
+		vec4.T{f / aspect, 0, 0, 0},
+		vec4.T{0, f, 0, 0},
+		vec4.T{0, 0, far * ooNearFar, -1},
+		vec4.T{0, 0, near * far * ooNearFar, 0},
+	}
+}
+
+// OrthoZO returns the same orthogonal projection as AssignOrthogonalProjection,
+// but maps the near plane to a normalized device Z of 0 instead of -1.
+func OrthoZO(left, right, bottom, top, near, far float64) T {
+	var mat T
+	mat.AssignOrthogonalProjection(left, right, bottom, top, near, far)
+	ooFarNear := 1 / (far - near)
+	mat[2][2] = -ooFarNear
+	mat[3][2] = -near * ooFarNear
+	return mat
+}
+
+// ClipSpaceConvention returns the ClipSpace of a perspective or orthogonal projection
+// created with the near plane distance near.
+// The matrix alone can't decide the convention, because every projection matrix
+// is valid in both conventions, only with different near planes,
+// so the convention is the one whose near plane matches near within a relative
+// tolerance of 1e-9.
+// ClipSpaceUnknown is returned for matrices that are no projections
+// or if neither convention has the near plane near.
+func (mat *T) ClipSpaceConvention(near float64) ClipSpace {
+	a, b := mat[2][2], mat[3][2]
+	if mat[0][2] != 0 || mat[1][2] != 0 || mat[0][3] != 0 || mat[1][3] != 0 {
+		return ClipSpaceUnknown
+	}
+	var nearNO, nearZO float64
+	switch {
+	case mat[2][3] == -1 && mat[3][3] == 0 && a <= -1:
+		nearNO, nearZO = b/(a-1), b/a
+	case mat[2][3] == 0 && mat[3][3] == 1 && a < 0:
+		nearNO, nearZO = (b+1)/a, b/a
+	default:
+		return ClipSpaceUnknown
+	}
+	tolerance := 1e-9 * math.Max(1, math.Abs(near))
+	switch {
+	case math.Abs(nearNO-near) <= tolerance:
+		return ClipSpaceNegativeOneToOne
+	case math.Abs(nearZO-near) <= tolerance:
+		return ClipSpaceZeroToOne
+	}
+	return ClipSpaceUnknown
+}
+
+// FlipHandedness converts the transformation m between a left-handed and a
+// right-handed coordinate system that differ by the sign of the axis 0 (X), 1 (Y) or 2 (Z).
+// Instead of only negating a column, m is conjugated by the reflection S about the axis
+// as S * m * S, so the result applied to reflected coordinates gives the reflected
+// result of m, which turns a rotation of the one convention into the equivalent
+// rotation of the other and keeps the determinant.
+// FlipHandedness panics if axis is not 0, 1 or 2.
+func FlipHandedness(m *T, axis int) T {
+	if axis < 0 || axis > 2 {
+		panic(fmt.Sprintf("mat4.FlipHandedness: axis %d out of range [0, 2]", axis))
+	}
+	sign := vec4.T{1, 1, 1, 1}
+	sign[axis] = -1
+	var result T
+	for col := range result {
+		for row := range result[col] {
+			result[col][row] = sign[col] * m[col][row] * sign[row]
+		}
+	}
+	return result
+}
+
+// Pixel2D returns an orthogonal projection for 2D rendering that maps pixel coordinates
+// from (0, 0) to (screenWidth, screenHeight) to the NDC range from -1 to 1.
+// Integer coordinates are the corners between pixels, so quads placed at integer
+// coordinates with integer sizes cover whole pixels and sample texels crisply.
+// With flipY the origin is the top-left corner and Y grows downwards,
+// else the origin is the bottom-left corner.
+// Z values from -1 to 1 are kept in the NDC depth range.
+func Pixel2D(screenWidth, screenHeight float64, flipY bool) T {
+	bottom, top := 0.0, screenHeight
+	if flipY {
+		bottom, top = screenHeight, 0
+	}
+	var mat T
+	mat.AssignOrthogonalProjection(0, screenWidth, bottom, top, -1, 1)
+	return mat
+}
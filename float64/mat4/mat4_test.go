@@ -0,0 +1,987 @@
+package mat4
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/quaternion"
+	"github.com/ungerik/go3d/float64/vec3"
+	"github.com/ungerik/go3d/float64/vec4"
+)
+
+const EPSILON = 0.000001
+
+func vec3Equal(a, b *vec3.T, epsilon float64) bool {
+	return math.Abs(a[0]-b[0]) <= epsilon && math.Abs(a[1]-b[1]) <= epsilon && math.Abs(a[2]-b[2]) <= epsilon
+}
+
+func matEqual(a, b *T, epsilon float64) bool {
+	for col := range a {
+		for row := range a[col] {
+			if math.Abs(a[col][row]-b[col][row]) > epsilon {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestJitter(t *testing.T) {
+	var proj T
+	proj.AssignPerspectiveProjection(-1, 1, -1, 1, 1, 100)
+	jittered := proj.Jitter(0.25, -0.5)
+
+	for _, p := range []vec3.T{{0, 0, -2}, {1, -3, -10}, {-4, 2, -50}} {
+		v := vec4.FromVec3(&p)
+		a := proj.MulVec4(&v)
+		b := jittered.MulVec4(&v)
+		if math.Abs(a[3]-b[3]) > EPSILON {
+			t.Errorf("jitter changed w for %v: %v != %v", p, a[3], b[3])
+		}
+		ndcA := a.Vec3DividedByW()
+		ndcB := b.Vec3DividedByW()
+		want := vec3.T{ndcA[0] + 0.25, ndcA[1] - 0.5, ndcA[2]}
+		if !vec3Equal(&ndcB, &want, EPSILON) {
+			t.Errorf("jittered projection of %v is %v, want %v", p, ndcB, want)
+		}
+	}
+}
+
+func TestExtractScale(t *testing.T) {
+	var m T
+	m.AssignEulerRotation(0.5, 0.2, -0.3)
+	m.Translate(&vec3.T{1, 2, 3})
+	scale := vec3.T{2, 3, 4}
+	for col := 0; col < 3; col++ {
+		m[col].Scale(scale[col])
+	}
+	if got := m.ExtractScale(); !vec3Equal(&got, &scale, EPSILON) {
+		t.Errorf("ExtractScale returned %v, want %v", got, scale)
+	}
+
+	m[1].Scale(-1)
+	got := m.ExtractScale()
+	if want := (vec3.T{-2, 3, 4}); !vec3Equal(&got, &want, EPSILON) {
+		t.Errorf("ExtractScale of mirrored matrix returned %v, want %v", got, want)
+	}
+	var rotation T
+	for col := 0; col < 3; col++ {
+		rotation[col] = m[col].Scaled(1 / got[col])
+	}
+	if det := rotation.Determinant3x3(); math.Abs(det-1) > EPSILON {
+		t.Errorf("remaining rotation has determinant %v, want 1", det)
+	}
+}
+
+func TestProjectPoints(t *testing.T) {
+	var proj T
+	proj.AssignPerspectiveProjection(-1, 1, -1, 1, 1, 100)
+	src := []vec3.T{
+		{0, 0, -10},  // inside
+		{15, 5, -10}, // outside the side planes
+		{0, 0, -0.5}, // in front of the near plane
+		{0, 0, -200}, // behind the far plane
+		{0, 0, 10},   // behind the viewer
+		{-9, 9, -10}, // inside, near the corner
+	}
+	want := []bool{true, false, false, false, false, true}
+	dst := make([]vec3.T, len(src))
+	mask := ProjectPoints(dst, src, &proj)
+	for i := range src {
+		if mask[i] != want[i] {
+			t.Errorf("clip mask of %v is %v, want %v", src[i], mask[i], want[i])
+		}
+		if want[i] {
+			expected := proj.MulVec3(&src[i])
+			if !vec3Equal(&dst[i], &expected, EPSILON) {
+				t.Errorf("projection of %v is %v, want %v", src[i], dst[i], expected)
+			}
+		}
+	}
+}
+
+func TestRowAndColumnMajor(t *testing.T) {
+	m := Ident
+	m.SetTranslation(&vec3.T{1, 2, 3})
+
+	columnMajor := [16]float64{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 1, 2, 3, 1}
+	rowMajor := [16]float64{1, 0, 0, 1, 0, 1, 0, 2, 0, 0, 1, 3, 0, 0, 0, 1}
+
+	if got := m.AsColumnMajor(); got != columnMajor {
+		t.Errorf("AsColumnMajor returned %v, want %v", got, columnMajor)
+	}
+	if got := m.AsRowMajor(); got != rowMajor {
+		t.Errorf("AsRowMajor returned %v, want %v", got, rowMajor)
+	}
+	if got := FromColumnMajor(columnMajor); got != m {
+		t.Errorf("FromColumnMajor returned %v, want %v", &got, &m)
+	}
+	if got := FromRowMajor(rowMajor); got != m {
+		t.Errorf("FromRowMajor returned %v, want %v", &got, &m)
+	}
+
+	fromColumns, err := FromSlice(columnMajor[:], true)
+	if err != nil || fromColumns != m {
+		t.Errorf("FromSlice of column major data returned %v, %v, want %v", &fromColumns, err, &m)
+	}
+	fromRows, err := FromSlice(rowMajor[:], false)
+	if err != nil || fromRows != fromColumns {
+		t.Errorf("FromSlice of row major data returned %v, %v, want %v", &fromRows, err, &m)
+	}
+	if _, err := FromSlice(columnMajor[:15], true); err == nil {
+		t.Errorf("FromSlice with 15 values returned no error")
+	}
+}
+
+func TestReflection(t *testing.T) {
+	xz := vec4.T{0, 1, 0, 0}
+	m := Reflection(&xz)
+	p := vec3.T{1, 2, 3}
+	if got, want := m.MulVec3(&p), (vec3.T{1, -2, 3}); !vec3Equal(&got, &want, EPSILON) {
+		t.Errorf("reflection across the XZ plane is %v, want %v", got, want)
+	}
+
+	// plane x = 2
+	offset := vec4.T{1, 0, 0, -2}
+	m = Reflection(&offset)
+	if got, want := m.MulVec3(&p), (vec3.T{3, 2, 3}); !vec3Equal(&got, &want, EPSILON) {
+		t.Errorf("reflection across the plane x=2 is %v, want %v", got, want)
+	}
+	onPlane := vec3.T{2, -5, 7}
+	if got := m.MulVec3(&onPlane); !vec3Equal(&got, &onPlane, EPSILON) {
+		t.Errorf("point on the plane moved from %v to %v", onPlane, got)
+	}
+}
+
+func TestPerspectiveInfinite(t *testing.T) {
+	m := PerspectiveInfinite(math.Pi/2, 1, 1)
+	near := vec3.T{0, 0, -1}
+	if got := m.MulVec3(&near); math.Abs(got[2]+1) > EPSILON {
+		t.Errorf("near plane maps to depth %v, want -1", got[2])
+	}
+	for _, z := range []float64{-1e3, -1e6, -1e12} {
+		p := vec3.T{0, 0, z}
+		v := vec4.FromVec3(&p)
+		clip := m.MulVec4(&v)
+		depth := clip[2] / clip[3]
+		if depth >= 1 || depth < 0.99 || clip[2] > clip[3] {
+			t.Errorf("point at z=%v maps to depth %v, want just below 1", z, depth)
+		}
+	}
+
+	var finite T
+	finite.AssignPerspectiveProjection(-1, 1, -1, 1, 1, 1e9)
+	for col := range m {
+		for row := range m[col] {
+			if math.Abs(m[col][row]-finite[col][row]) > 0.0001 {
+				t.Errorf("element %d,%d is %v, want the limit %v", col, row, m[col][row], finite[col][row])
+			}
+		}
+	}
+}
+
+func TestShadowMatrix(t *testing.T) {
+	corner := vec3.T{-1, 1, -1}
+	if got, want := Bias.MulVec3(&corner), (vec3.T{0, 1, 0}); !vec3Equal(&got, &want, EPSILON) {
+		t.Errorf("bias maps NDC corner %v to %v, want %v", corner, got, want)
+	}
+	var lightViewProj T
+	lightViewProj.AssignOrthogonalProjection(-10, 10, -10, 10, 1, 21)
+	m := ShadowMatrix(&lightViewProj)
+	p := vec3.T{10, -10, -11}
+	if got, want := m.MulVec3(&p), (vec3.T{1, 0, 0.5}); !vec3Equal(&got, &want, EPSILON) {
+		t.Errorf("shadow matrix maps %v to %v, want %v", p, got, want)
+	}
+}
+
+func TestViewFromForwardUp(t *testing.T) {
+	position := vec3.T{1, 2, 3}
+	forward := vec3.T{1, 0, -1}
+	view := ViewFromForwardUp(&position, &forward, &vec3.UnitY)
+	if got := view.MulVec3(&position); !vec3Equal(&got, &vec3.Zero, EPSILON) {
+		t.Errorf("position maps to %v, want the origin", got)
+	}
+	f := view.MulVec3W(&forward, 0)
+	f.Normalize()
+	if want := (vec3.T{0, 0, -1}); !vec3Equal(&f, &want, EPSILON) {
+		t.Errorf("forward maps to %v, want %v", f, want)
+	}
+	u := view.MulVec3W(&vec3.UnitY, 0)
+	if u[0] != 0 || u[1] <= 0 {
+		t.Errorf("up maps to %v, want positive Y", u)
+	}
+
+	down := vec3.T{0, -2, 0}
+	view = ViewFromForwardUp(&position, &down, &vec3.UnitY)
+	f = view.MulVec3W(&down, 0)
+	f.Normalize()
+	if want := (vec3.T{0, 0, -1}); !vec3Equal(&f, &want, EPSILON) {
+		t.Errorf("forward parallel to up maps to %v, want %v", f, want)
+	}
+	if det := view.Determinant3x3(); math.Abs(det-1) > EPSILON {
+		t.Errorf("view matrix for forward parallel to up has determinant %v", det)
+	}
+}
+
+func TestDecomposeFull(t *testing.T) {
+	translation := vec3.T{1, -2, 3}
+	rotation := quaternion.FromEulerAngles(0.4, -0.3, 1.2)
+	shear := vec3.T{0.5, -0.25, 0.75}
+	scale := vec3.T{2, 3, 0.5}
+
+	// rotate * shear * scale applied to the unit axes
+	var m T
+	m.AssignQuaternion(&rotation)
+	r0, r1, r2 := m[0].Vec3(), m[1].Vec3(), m[2].Vec3()
+	c0 := r0.Scaled(scale[0])
+	c1 := r0.Scaled(shear[0])
+	c1.Add(&r1).Scale(scale[1])
+	c2 := r0.Scaled(shear[1])
+	r1s := r1.Scaled(shear[2])
+	c2.Add(&r1s).Add(&r2).Scale(scale[2])
+	m[0].AssignVec3(&c0)[3] = 0
+	m[1].AssignVec3(&c1)[3] = 0
+	m[2].AssignVec3(&c2)[3] = 0
+	m.SetTranslation(&translation)
+
+	gotT, gotR, gotShear, gotScale, ok := m.DecomposeFull()
+	if !ok {
+		t.Fatal("DecomposeFull failed")
+	}
+	if !vec3Equal(&gotT, &translation, EPSILON) {
+		t.Errorf("translation is %v, want %v", gotT, translation)
+	}
+	if d := math.Abs(quaternion.Dot(&gotR, &rotation)); math.Abs(d-1) > EPSILON {
+		t.Errorf("rotation is %v, want %v", gotR, rotation)
+	}
+	if !vec3Equal(&gotShear, &shear, EPSILON) {
+		t.Errorf("shear is %v, want %v", gotShear, shear)
+	}
+	if !vec3Equal(&gotScale, &scale, EPSILON) {
+		t.Errorf("scale is %v, want %v", gotScale, scale)
+	}
+
+	projective := Ident
+	projective[2][3] = -1
+	if _, _, _, _, ok := projective.DecomposeFull(); ok {
+		t.Error("DecomposeFull must fail for a projective matrix")
+	}
+}
+
+func TestWorldToLocal(t *testing.T) {
+	origin := vec3.T{3, -1, 2}
+	x := vec3.T{0, 0, -1}
+	y := vec3.T{1, 0, 0}
+	z := vec3.T{0, -1, 0}
+	worldToLocal := WorldToLocal(&origin, &x, &y, &z)
+	localToWorld := LocalToWorld(&origin, &x, &y, &z)
+
+	p := vec3.T{4, 1, -3}
+	local := worldToLocal.MulVec3(&p)
+	// local coordinates are the projections of p-origin onto the axes
+	d := vec3.Sub(&p, &origin)
+	if want := (vec3.T{vec3.Dot(&d, &x), vec3.Dot(&d, &y), vec3.Dot(&d, &z)}); !vec3Equal(&local, &want, EPSILON) {
+		t.Errorf("local coordinates are %v, want %v", local, want)
+	}
+	if world := localToWorld.MulVec3(&local); !vec3Equal(&world, &p, EPSILON) {
+		t.Errorf("round trip gives %v, want %v", world, p)
+	}
+	if o := worldToLocal.MulVec3(&origin); !vec3Equal(&o, &vec3.Zero, EPSILON) {
+		t.Errorf("origin maps to %v, want zero", o)
+	}
+}
+
+func TestAddScaled(t *testing.T) {
+	a := Ident
+	a.SetTranslation(&vec3.T{2, 0, 0})
+	b := Ident
+	b.SetTranslation(&vec3.T{0, 4, 0})
+	b[0][0] = 3
+
+	var sum T
+	sum.AddScaled(&a, 0.25).AddScaled(&b, 0.75)
+	for col := range sum {
+		for row := range sum[col] {
+			want := a[col][row]*0.25 + b[col][row]*0.75
+			if math.Abs(sum[col][row]-want) > EPSILON {
+				t.Fatalf("element [%d][%d] is %v, want %v", col, row, sum[col][row], want)
+			}
+		}
+	}
+	if got, want := sum.MulVec3(&vec3.Zero), (vec3.T{0.5, 3, 0}); !vec3Equal(&got, &want, EPSILON) {
+		t.Errorf("blended translation is %v, want %v", got, want)
+	}
+}
+
+func TestPerspectiveOffCenter(t *testing.T) {
+	const near, far = 0.5, 100.0
+	m := PerspectiveFromTangents(1, 0.6, 0.9, 1.2, near, far)
+	corners := []struct {
+		point vec3.T
+		ndc   vec3.T
+	}{
+		{vec3.T{-1 * near, -1.2 * near, -near}, vec3.T{-1, -1, -1}},
+		{vec3.T{0.6 * near, -1.2 * near, -near}, vec3.T{1, -1, -1}},
+		{vec3.T{-1 * near, 0.9 * near, -near}, vec3.T{-1, 1, -1}},
+		{vec3.T{0.6 * near, 0.9 * near, -near}, vec3.T{1, 1, -1}},
+		{vec3.T{0.6 * far, 0.9 * far, -far}, vec3.T{1, 1, 1}},
+	}
+	for _, c := range corners {
+		clip := m.MulVec4(&vec4.T{c.point[0], c.point[1], c.point[2], 1})
+		if ndc := clip.Vec3DividedByW(); !vec3Equal(&ndc, &c.ndc, EPSILON) {
+			t.Errorf("corner %v projects to %v, want %v", c.point, ndc, c.ndc)
+		}
+	}
+
+	symmetric := PerspectiveOffCenter(-1, 1, -1, 1, near, far)
+	if symmetric[2][0] != 0 || symmetric[2][1] != 0 {
+		t.Errorf("symmetric frustum has off center terms: %v", symmetric)
+	}
+}
+
+func TestInvert(t *testing.T) {
+	m := ViewFromForwardUp(&vec3.T{1, 2, 3}, &vec3.T{0.3, -0.2, -1}, &vec3.UnitY)
+	m.ScaleVec3(&vec3.T{2, 3, 4})
+	inv := m.Inverted()
+	var prod T
+	prod.AssignMul(&m, &inv)
+	if !matEqual(&prod, &Ident, EPSILON) {
+		t.Fatalf("m * m⁻¹ is %v, want identity", prod)
+	}
+}
+
+func TestImpostorMatrix(t *testing.T) {
+	position := vec3.T{1, 2, 3}
+	cameraPos := vec3.T{4, 6, 3}
+	m := ImpostorMatrix(&position, &cameraPos, &vec3.UnitZ, 2, 0.5)
+
+	corner := func(x, y float64) vec3.T {
+		v := vec3.T{x, y, 0}
+		m.TransformVec3(&v)
+		return v
+	}
+	center := corner(0, 0)
+	if d := vec3.Distance(&center, &position); d > 1e-12 {
+		t.Errorf("quad center is %v, want %v", center, position)
+	}
+	lb, rb, lt := corner(-0.5, -0.5), corner(0.5, -0.5), corner(-0.5, 0.5)
+	if w := vec3.Distance(&lb, &rb); math.Abs(w-2) > 1e-12 {
+		t.Errorf("quad width is %v, want 2", w)
+	}
+	if h := vec3.Distance(&lb, &lt); math.Abs(h-0.5) > 1e-12 {
+		t.Errorf("quad height is %v, want 0.5", h)
+	}
+	// the front face normal of the transformed quad points at the camera
+	right := vec3.Sub(&rb, &lb)
+	up := vec3.Sub(&lt, &lb)
+	normal := vec3.Cross(&right, &up)
+	normal.Normalize()
+	toCamera := vec3.Sub(&cameraPos, &position)
+	toCamera.Normalize()
+	if d := vec3.Dot(&normal, &toCamera); math.Abs(d-1) > 1e-12 {
+		t.Errorf("quad normal %v does not face the camera direction %v", normal, toCamera)
+	}
+	if up[2] <= 0 {
+		t.Errorf("quad up %v is not aligned with the camera up", up)
+	}
+
+	// camera straight above with a parallel up direction
+	above := vec3.T{1, 2, 10}
+	m = ImpostorMatrix(&position, &above, &vec3.UnitZ, 1, 1)
+	for _, col := range m {
+		for _, f := range col {
+			if math.IsNaN(f) {
+				t.Fatalf("parallel up direction gives NaN matrix %v", m)
+			}
+		}
+	}
+}
+
+func TestFrustumCorners(t *testing.T) {
+	const near, far = 1.0, 10.0
+	eye := vec3.T{5, 1, 0}
+	view := ViewFromForwardUp(&eye, &vec3.T{-1, 0, 0}, &vec3.UnitY)
+	proj := PerspectiveOffCenter(-1, 1, -0.5, 0.5, near, far)
+	var viewProj T
+	viewProj.AssignMul(&proj, &view)
+	inv := viewProj.Inverted()
+	corners := FrustumCorners(&inv)
+
+	// the camera looks down -X, so the near plane is at x = 4 and the far plane at x = -5
+	if want := (vec3.T{4, 0.5, 1}); !vec3Equal(&corners[0], &want, EPSILON) {
+		t.Errorf("left bottom near corner is %v, want %v", corners[0], want)
+	}
+	if want := (vec3.T{-5, 6, -10}); !vec3Equal(&corners[7], &want, EPSILON) {
+		t.Errorf("right top far corner is %v, want %v", corners[7], want)
+	}
+
+	var min, max vec3.T = vec3.MaxVal, vec3.MinVal
+	for i := range corners {
+		min = vec3.Min(&min, &corners[i])
+		max = vec3.Max(&max, &corners[i])
+	}
+	inside := vec3.T{0, 1, 0}
+	for i := range inside {
+		if inside[i] < min[i] || inside[i] > max[i] {
+			t.Errorf("point %v in the frustum is not enclosed by the corners %v", inside, corners)
+		}
+	}
+}
+
+func TestLookAt(t *testing.T) {
+	eye := vec3.T{1, 2, 3}
+	center := vec3.T{4, 2, -1}
+	up := vec3.UnitY
+	// the target is at distance 5 in front of the camera
+	rh := LookAt(&eye, &center, &up)
+	if got, want := rh.MulVec3(&center), (vec3.T{0, 0, -5}); !vec3Equal(&got, &want, EPSILON) {
+		t.Errorf("right-handed view space target is %v, want %v", got, want)
+	}
+	lh := LookAtLH(&eye, &center, &up)
+	if got, want := lh.MulVec3(&center), (vec3.T{0, 0, 5}); !vec3Equal(&got, &want, EPSILON) {
+		t.Errorf("left-handed view space target is %v, want %v", got, want)
+	}
+
+	// both keep Y up, but the world X axis that is to the right flips with the handedness
+	above := vec3.T{1, 3, 3}
+	side := vec3.T{1 + 0.8, 2, 3 + 0.6}
+	for _, m := range []T{rh, lh} {
+		if got := m.MulVec3(&above); math.Abs(got[1]-1) > EPSILON {
+			t.Errorf("point above the eye is at %v, want Y = 1", got)
+		}
+	}
+	if got := rh.MulVec3(&side); math.Abs(got[0]-1) > EPSILON {
+		t.Errorf("right-handed view space side point is %v, want X = 1", got)
+	}
+	if got := lh.MulVec3(&side); math.Abs(got[0]+1) > EPSILON {
+		t.Errorf("left-handed view space side point is %v, want X = -1", got)
+	}
+}
+
+func TestPow(t *testing.T) {
+	m := ViewFromForwardUp(&vec3.T{1, -2, 3}, &vec3.T{0.2, 0.4, -1}, &vec3.UnitY)
+	m.ScaleVec3(&vec3.T{1.5, 0.5, 2})
+	if p, err := m.Pow(0); err != nil || p != Ident {
+		t.Errorf("Pow(0) is %v (%v), want identity", p, err)
+	}
+	var sq T
+	sq.AssignMul(&m, &m)
+	if p, err := m.Pow(2); err != nil || !matEqual(&p, &sq, EPSILON) {
+		t.Errorf("Pow(2) is %v (%v), want %v", p, err, sq)
+	}
+	var cube T
+	cube.AssignMul(&sq, &m)
+	if p, err := m.Pow(3); err != nil || !matEqual(&p, &cube, EPSILON) {
+		t.Errorf("Pow(3) is %v (%v), want %v", p, err, cube)
+	}
+	inv := m.Inverted()
+	if p, err := m.Pow(-1); err != nil || !matEqual(&p, &inv, EPSILON) {
+		t.Errorf("Pow(-1) is %v (%v), want %v", p, err, inv)
+	}
+
+	if _, err := Zero.Pow(-2); err == nil {
+		t.Error("negative power of a singular matrix must fail")
+	}
+}
+
+func TestPerspectiveZoom(t *testing.T) {
+	const fovy, aspect, near, far = math.Pi / 2, 1.5, 0.1, 100.0
+	base := Perspective(fovy, aspect, near, far)
+	if m := PerspectiveZoom(fovy, 1, aspect, near, far); m != base {
+		t.Errorf("zoom 1 gives %v, want %v", m, base)
+	}
+
+	// a point at the top edge of the field of view projects to NDC Y = 1
+	edgeY := func(m *T, halfAngle float64) float64 {
+		clip := m.MulVec4(&vec4.T{0, math.Tan(halfAngle) * 10, -10, 1})
+		ndc := clip.Vec3DividedByW()
+		return ndc[1]
+	}
+	if y := edgeY(&base, fovy/2); math.Abs(y-1) > EPSILON {
+		t.Errorf("base field of view edge projects to %v, want 1", y)
+	}
+	zoomed := PerspectiveZoom(fovy, 2, aspect, near, far)
+	if y := edgeY(&zoomed, fovy/4); math.Abs(y-1) > EPSILON {
+		t.Errorf("zoomed field of view edge projects to %v, want 1", y)
+	}
+	if y := edgeY(&zoomed, fovy/2); y <= 1 {
+		t.Errorf("base field of view edge must be outside of the zoomed view, projects to %v", y)
+	}
+}
+
+func TestTwoPointPerspective(t *testing.T) {
+	eye := vec3.T{0, 1.7, 0}
+	view := LookAt(&eye, &vec3.T{3, 1.7, -10}, &vec3.UnitY)
+	proj := TwoPointPerspective(math.Pi/3, 1.5, 0.1, 1000, 0.4)
+	var viewProj T
+	viewProj.AssignMul(&proj, &view)
+	project := func(p vec3.T) vec3.T {
+		clip := viewProj.MulVec4(&vec4.T{p[0], p[1], p[2], 1})
+		return clip.Vec3DividedByW()
+	}
+
+	// the vertical edges of a tall box keep a constant screen X
+	for _, corner := range []vec3.T{{2, 0, -10}, {4, 0, -10}, {2, 0, -12}, {4, 0, -12}} {
+		top := corner
+		top[1] = 30
+		bottom, upper := project(corner), project(top)
+		if math.Abs(bottom[0]-upper[0]) > EPSILON {
+			t.Errorf("vertical edge at %v projects from x=%v to x=%v", corner, bottom[0], upper[0])
+		}
+	}
+
+	if horizon := project(vec3.T{300, 1.7, -1000}); math.Abs(horizon[1]+0.4) > EPSILON {
+		t.Errorf("horizon projects to y=%v, want -0.4", horizon[1])
+	}
+}
+
+func TestNormalMatrix3(t *testing.T) {
+	var rot T
+	rot.AssignEulerRotation(0.3, -0.7, 1.1)
+	scale := Ident
+	scale.ScaleVec3(&vec3.T{3, 0.5, -2})
+	var m T
+	m.AssignMul(&rot, &scale)
+	m.Translate(&vec3.T{4, 5, 6})
+
+	normalMatrix := m.NormalMatrix3()
+	normal := vec3.T{1, 2, 3}
+	normal.Normalize()
+	tangents := []vec3.T{{2, -1, 0}, {3, 0, -1}, {0, 3, -2}}
+	n := normalMatrix.MulVec3(&normal)
+	for _, tangent := range tangents {
+		if d := vec3.Dot(&normal, &tangent); math.Abs(d) > EPSILON {
+			t.Fatalf("tangent %v is not perpendicular to %v", tangent, normal)
+		}
+		// tangents are directions, so they ignore the translation
+		tv := vec4.T{tangent[0], tangent[1], tangent[2], 0}
+		tw := m.MulVec4(&tv)
+		transformed := tw.Vec3()
+		if d := vec3.Dot(&n, &transformed); math.Abs(d) > EPSILON {
+			t.Errorf("transformed normal %v is not perpendicular to transformed tangent %v: %v", n, transformed, d)
+		}
+	}
+}
+
+func TestSnapTransform(t *testing.T) {
+	var m T
+	m.AssignEulerRotation(math.Pi/2+0.01, 0.02, -0.01)
+	for i := 0; i < 3; i++ {
+		m[i].Scale(2)
+	}
+	m.SetTranslation(&vec3.T{1.02, 2.97, -0.01})
+
+	snapped := SnapTransform(&m, 0.5, math.Pi/4)
+
+	var want T
+	want.AssignEulerRotation(math.Pi/2, 0, 0)
+	for i := 0; i < 3; i++ {
+		want[i].Scale(2)
+	}
+	want.SetTranslation(&vec3.T{1, 3, 0})
+	if !matEqual(&snapped, &want, EPSILON) {
+		t.Fatalf("SnapTransform returned %v, want %v", snapped, want)
+	}
+}
+
+func TestValidateDecomposition(t *testing.T) {
+	var m T
+	m.AssignEulerRotation(0.4, -1.1, 2.3)
+	m[0].Scale(2)
+	m[1].Scale(0.5)
+	m[2].Scale(-3)
+	m.SetTranslation(&vec3.T{1, -2, 3})
+	if !m.ValidateDecomposition(1e-9) {
+		t.Errorf("clean TRS matrix %v does not validate", m)
+	}
+
+	sheared := m
+	sheared[1][0] += 0.3
+	if sheared.ValidateDecomposition(1e-9) {
+		t.Errorf("sheared matrix %v validates", sheared)
+	}
+
+	var projection T
+	projection.AssignPerspectiveProjection(-1, 1, -1, 1, 1, 10)
+	if projection.ValidateDecomposition(1e-9) {
+		t.Error("projection matrix validates")
+	}
+}
+
+func TestScrewInterpolate(t *testing.T) {
+	var a T
+	a.AssignEulerRotation(0.3, -0.5, 0.8)
+	a.SetTranslation(&vec3.T{1, 2, 3})
+
+	// a quarter turn about the Z axis through (1, 0, 0) combined with a translation of 2 along Z
+	screw := func(t float64) T {
+		var m T
+		m.AssignZRotation(t * math.Pi / 2)
+		c := vec3.T{1, 0, 0}
+		rc := m.MulVec3W(&c, 0)
+		m.SetTranslation(&vec3.T{c[0] - rc[0], c[1] - rc[1], 2 * t})
+		return m
+	}
+	end := screw(1)
+	var b T
+	b.AssignMul(&end, &a)
+
+	if m := ScrewInterpolate(&a, &b, 0); !matEqual(&m, &a, EPSILON) {
+		t.Errorf("ScrewInterpolate at 0 is %v, want %v", m, a)
+	}
+	if m := ScrewInterpolate(&a, &b, 1); !matEqual(&m, &b, EPSILON) {
+		t.Errorf("ScrewInterpolate at 1 is %v, want %v", m, b)
+	}
+	for _, f := range []float64{0.25, 0.5, 0.75} {
+		s := screw(f)
+		var want T
+		want.AssignMul(&s, &a)
+		if m := ScrewInterpolate(&a, &b, f); !matEqual(&m, &want, EPSILON) {
+			t.Errorf("ScrewInterpolate at %v is %v, want %v", f, m, want)
+		}
+	}
+
+	// pure translation
+	c := a
+	c.Translate(&vec3.T{4, 0, 0})
+	want := a
+	want.Translate(&vec3.T{1, 0, 0})
+	if m := ScrewInterpolate(&a, &c, 0.25); !matEqual(&m, &want, EPSILON) {
+		t.Errorf("ScrewInterpolate of a translation is %v, want %v", m, want)
+	}
+}
+
+func TestSkinMatrices(t *testing.T) {
+	world := make([]T, 3)
+	inverseBind := make([]T, 3)
+	for i := range world {
+		world[i].AssignEulerRotation(float64(i)*0.4, 0.2, -0.3)
+		world[i].SetTranslation(&vec3.T{float64(i), 2, -1})
+		var bind T
+		bind.AssignYRotation(float64(i) * -0.7)
+		bind.SetTranslation(&vec3.T{0, float64(i), 1})
+		inverseBind[i] = bind.Inverted()
+	}
+
+	vertex := vec3.T{0.5, 1.5, -2}
+	skin := SkinMatrix(&world[1], &inverseBind[1])
+	local := inverseBind[1].MulVec3(&vertex)
+	want := world[1].MulVec3(&local)
+	if got := skin.MulVec3(&vertex); !vec3Equal(&got, &want, EPSILON) {
+		t.Errorf("SkinMatrix transforms %v to %v, want %v", vertex, got, want)
+	}
+
+	dst := make([]T, 3)
+	SkinMatrices(dst, world, inverseBind)
+	for i := range dst {
+		if want := SkinMatrix(&world[i], &inverseBind[i]); dst[i] != want {
+			t.Errorf("SkinMatrices[%d] is %v, want %v", i, dst[i], want)
+		}
+	}
+}
+
+func TestPerspectiveParams(t *testing.T) {
+	proj := Perspective(1.2, 16.0/9, 0.1, 500)
+	fovy, aspect, near, far, ok := proj.PerspectiveParams()
+	if !ok {
+		t.Fatalf("PerspectiveParams failed for %v", proj)
+	}
+	if math.Abs(fovy-1.2) > EPSILON || math.Abs(aspect-16.0/9) > EPSILON ||
+		math.Abs(near-0.1) > EPSILON || math.Abs(far-500) > 1e-6*500 {
+		t.Errorf("PerspectiveParams returned %v, %v, %v, %v, want 1.2, %v, 0.1, 500", fovy, aspect, near, far, 16.0/9)
+	}
+
+	infinite := PerspectiveInfinite(0.8, 1.5, 0.5)
+	fovy, aspect, near, far, ok = infinite.PerspectiveParams()
+	if !ok || math.Abs(fovy-0.8) > EPSILON || math.Abs(aspect-1.5) > EPSILON ||
+		math.Abs(near-0.5) > EPSILON || !math.IsInf(far, 1) {
+		t.Errorf("PerspectiveParams of infinite projection returned %v, %v, %v, %v, %v", fovy, aspect, near, far, ok)
+	}
+
+	var ortho T
+	ortho.AssignOrthogonalProjection(-1, 1, -1, 1, 0.1, 100)
+	if _, _, _, _, ok := ortho.PerspectiveParams(); ok {
+		t.Errorf("PerspectiveParams succeeded for an orthogonal projection")
+	}
+	offCenter := PerspectiveOffCenter(-1, 2, -1, 1, 0.1, 100)
+	if _, _, _, _, ok := offCenter.PerspectiveParams(); ok {
+		t.Errorf("PerspectiveParams succeeded for an off center projection")
+	}
+}
+
+func TestApplyAdditive(t *testing.T) {
+	compose := func(translation *vec3.T, rotation *quaternion.T, scale *vec3.T) T {
+		var m T
+		m.AssignQuaternion(rotation)
+		for i := 0; i < 3; i++ {
+			m[i].Scale(scale[i])
+		}
+		m.SetTranslation(translation)
+		return m
+	}
+	rb := quaternion.FromEulerAngles(0.4, 0.1, -0.3)
+	base := compose(&vec3.T{1, 2, 3}, &rb, &vec3.T{2, 2, 1})
+	rd := quaternion.FromYAxisAngle(0.6)
+	delta := compose(&vec3.T{0, 1, -1}, &rd, &vec3.T{1.5, 1, 1})
+
+	if m := ApplyAdditive(&base, &delta, 0); !matEqual(&m, &base, EPSILON) {
+		t.Errorf("ApplyAdditive with weight 0 is %v, want %v", m, base)
+	}
+	r := quaternion.Mul(&rd, &rb)
+	want := compose(&vec3.T{1, 3, 2}, &r, &vec3.T{3, 2, 1})
+	if m := ApplyAdditive(&base, &delta, 1); !matEqual(&m, &want, EPSILON) {
+		t.Errorf("ApplyAdditive with weight 1 is %v, want %v", m, want)
+	}
+	rh := quaternion.FromYAxisAngle(0.3)
+	r = quaternion.Mul(&rh, &rb)
+	want = compose(&vec3.T{1, 2.5, 2.5}, &r, &vec3.T{2.5, 2, 1})
+	if m := ApplyAdditive(&base, &delta, 0.5); !matEqual(&m, &want, EPSILON) {
+		t.Errorf("ApplyAdditive with weight 0.5 is %v, want %v", m, want)
+	}
+}
+
+func TestClipSpaceZO(t *testing.T) {
+	depth := func(m *T, z float64) float64 {
+		v := vec4.T{0.3, -0.2, z, 1}
+		c := m.MulVec4(&v)
+		return c[2] / c[3]
+	}
+
+	perspective := PerspectiveZO(1, 1.5, 0.1, 100)
+	if d := depth(&perspective, -0.1); math.Abs(d) > EPSILON {
+		t.Errorf("PerspectiveZO maps the near plane to %v, want 0", d)
+	}
+	if d := depth(&perspective, -100); math.Abs(d-1) > EPSILON {
+		t.Errorf("PerspectiveZO maps the far plane to %v, want 1", d)
+	}
+	ortho := OrthoZO(-2, 2, -1, 1, 0.5, 50)
+	if d := depth(&ortho, -0.5); math.Abs(d) > EPSILON {
+		t.Errorf("OrthoZO maps the near plane to %v, want 0", d)
+	}
+	if d := depth(&ortho, -50); math.Abs(d-1) > EPSILON {
+		t.Errorf("OrthoZO maps the far plane to %v, want 1", d)
+	}
+
+	if c := perspective.ClipSpaceConvention(0.1); c != ClipSpaceZeroToOne {
+		t.Errorf("PerspectiveZO has the convention %v", c)
+	}
+	if c := ortho.ClipSpaceConvention(0.5); c != ClipSpaceZeroToOne {
+		t.Errorf("OrthoZO has the convention %v", c)
+	}
+	gl := Perspective(1, 1.5, 0.1, 100)
+	if c := gl.ClipSpaceConvention(0.1); c != ClipSpaceNegativeOneToOne {
+		t.Errorf("Perspective has the convention %v", c)
+	}
+	// non round near planes are detected as well
+	gl = Perspective(1, 1.5, 0.1234567, 98.7654)
+	if c := gl.ClipSpaceConvention(0.1234567); c != ClipSpaceNegativeOneToOne {
+		t.Errorf("Perspective with a non round near plane has the convention %v", c)
+	}
+	perspective = PerspectiveZO(1, 1.5, 0.1234567, 98.7654)
+	if c := perspective.ClipSpaceConvention(0.1234567); c != ClipSpaceZeroToOne {
+		t.Errorf("PerspectiveZO with a non round near plane has the convention %v", c)
+	}
+	var glOrtho T
+	glOrtho.AssignOrthogonalProjection(-2, 2, -1, 1, 0.5, 50)
+	if c := glOrtho.ClipSpaceConvention(0.5); c != ClipSpaceNegativeOneToOne {
+		t.Errorf("AssignOrthogonalProjection has the convention %v", c)
+	}
+	if c := gl.ClipSpaceConvention(0.5); c != ClipSpaceUnknown {
+		t.Errorf("projection with a different near plane has the convention %v", c)
+	}
+	if c := Ident.ClipSpaceConvention(0.1); c != ClipSpaceUnknown {
+		t.Errorf("ident matrix has the convention %v", c)
+	}
+}
+
+func TestCascadeSplits(t *testing.T) {
+	for _, lambda := range []float64{0, 0.5, 0.75, 1} {
+		splits := CascadeSplits(0.1, 1000, 4, lambda)
+		if len(splits) != 5 || splits[0] != 0.1 || splits[4] != 1000 {
+			t.Fatalf("CascadeSplits with lambda %v returned %v", lambda, splits)
+		}
+		for i := 1; i < len(splits); i++ {
+			if splits[i] <= splits[i-1] {
+				t.Errorf("CascadeSplits with lambda %v are not increasing: %v", lambda, splits)
+			}
+		}
+	}
+	uniform := CascadeSplits(0, 100, 4, 0)
+	for i, want := range []float64{0, 25, 50, 75, 100} {
+		if !(math.Abs(uniform[i]-want) <= EPSILON) {
+			t.Errorf("uniform splits are %v", uniform)
+			break
+		}
+	}
+	logarithmic := CascadeSplits(1, 10000, 4, 1)
+	for i, want := range []float64{1, 10, 100, 1000, 10000} {
+		if math.Abs(logarithmic[i]-want) > EPSILON*want {
+			t.Errorf("logarithmic splits are %v", logarithmic)
+			break
+		}
+	}
+}
+
+func TestReprojection(t *testing.T) {
+	proj := Perspective(1, 1.5, 0.1, 100)
+	eye := vec3.T{0, 1, 5}
+	target := vec3.T{0, 0, 0}
+	view := LookAt(&eye, &target, &vec3.UnitY)
+	var viewProj T
+	viewProj.AssignMul(&proj, &view)
+
+	m, err := Reprojection(&viewProj, &viewProj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !matEqual(&m, &Ident, EPSILON) {
+		t.Fatalf("reprojection with the same matrices is %v, want ident", m)
+	}
+
+	movedEye := vec3.T{0.2, 1, 5}
+	movedTarget := vec3.T{0.2, 0, 0}
+	previousView := LookAt(&movedEye, &movedTarget, &vec3.UnitY)
+	var previousViewProj T
+	previousViewProj.AssignMul(&proj, &previousView)
+	m, err = Reprojection(&viewProj, &previousViewProj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	point := vec4.T{0.5, 0.3, -1, 1}
+	current := viewProj.MulVec4(&point)
+	reprojected := m.MulVec4(&current)
+	want := previousViewProj.MulVec4(&point)
+	got3, want3 := reprojected.Vec3DividedByW(), want.Vec3DividedByW()
+	if !vec3Equal(&got3, &want3, EPSILON) {
+		t.Errorf("reprojected point is %v, want %v", got3, want3)
+	}
+	if want3[0] >= current.Vec3DividedByW()[0] {
+		t.Errorf("moving the camera right must move the point left in clip space")
+	}
+
+	if _, err := Reprojection(&Zero, &viewProj); err == nil {
+		t.Errorf("no error for a singular matrix")
+	}
+}
+
+func TestFlipHandedness(t *testing.T) {
+	// a left-handed transform with a Y rotation and a translation
+	var lh T
+	lh.AssignYRotation(0.7)
+	lh.SetTranslation(&vec3.T{1, 2, 3})
+
+	rh := FlipHandedness(&lh, 2)
+
+	// the same rotation expressed right-handed with Z flipped turns the other way
+	var want T
+	want.AssignYRotation(-0.7)
+	want.SetTranslation(&vec3.T{1, 2, -3})
+	if !matEqual(&rh, &want, 1e-12) {
+		t.Fatalf("FlipHandedness is %v, want %v", rh, want)
+	}
+
+	// converting and transforming commutes
+	p := vec3.T{0.5, -4, 2}
+	lhResult := p
+	lh.TransformVec3(&lhResult)
+	rhResult := vec3.T{p[0], p[1], -p[2]}
+	rh.TransformVec3(&rhResult)
+	if d := vec3.Distance(&rhResult, &vec3.T{lhResult[0], lhResult[1], -lhResult[2]}); d > 1e-12 {
+		t.Errorf("right-handed result %v does not match the flipped left-handed result %v", rhResult, lhResult)
+	}
+	if math.Abs(rh.Determinant()-lh.Determinant()) > 1e-12 {
+		t.Errorf("determinant changed from %v to %v", lh.Determinant(), rh.Determinant())
+	}
+	if back := FlipHandedness(&rh, 2); back != lh {
+		t.Errorf("flipping twice is %v, want %v", back, lh)
+	}
+
+	for _, axis := range []int{-1, 3} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("no panic for axis %d", axis)
+				}
+			}()
+			FlipHandedness(&lh, axis)
+		}()
+	}
+}
+
+func TestPixel2D(t *testing.T) {
+	const w, h = 800, 600
+	check := func(m *T, pixel vec3.T, want vec3.T) {
+		t.Helper()
+		v := m.MulVec4(&vec4.T{pixel[0], pixel[1], pixel[2], 1})
+		got := vec3.T{v[0] / v[3], v[1] / v[3], v[2] / v[3]}
+		if vec3.Distance(&got, &want) > 1e-12 {
+			t.Errorf("pixel %v maps to %v, want %v", pixel, got, want)
+		}
+	}
+	topLeft := Pixel2D(w, h, true)
+	check(&topLeft, vec3.T{0, 0, 0}, vec3.T{-1, 1, 0})
+	check(&topLeft, vec3.T{w, h, 0}, vec3.T{1, -1, 0})
+	check(&topLeft, vec3.T{w / 2, h / 2, 0}, vec3.T{0, 0, 0})
+
+	bottomLeft := Pixel2D(w, h, false)
+	check(&bottomLeft, vec3.T{0, 0, 0}, vec3.T{-1, -1, 0})
+	check(&bottomLeft, vec3.T{w, h, 0}, vec3.T{1, 1, 0})
+}
+
+func TestAssignUnitQuaternion(t *testing.T) {
+	for _, q := range []quaternion.T{
+		quaternion.Ident,
+		quaternion.FromEulerAngles(0.3, -1.1, 2.2),
+		quaternion.FromXAxisAngle(math.Pi),
+		quaternion.FromAxisAngle(&vec3.T{0.6, 0, 0.8}, -2.5),
+	} {
+		var safe, unit T
+		safe.AssignQuaternion(&q)
+		unit.AssignUnitQuaternion(&q)
+		if !matEqual(&safe, &unit, 1e-12) {
+			t.Errorf("AssignUnitQuaternion(%v) is %v, want %v", q, unit, safe)
+		}
+	}
+
+	// a quarter turn around Z maps X to Y
+	q := quaternion.FromZAxisAngle(math.Pi / 2)
+	var m T
+	m.AssignUnitQuaternion(&q)
+	want := T{
+		vec4.T{0, 1, 0, 0},
+		vec4.T{-1, 0, 0, 0},
+		vec4.T{0, 0, 1, 0},
+		vec4.T{0, 0, 0, 1},
+	}
+	if !matEqual(&m, &want, 1e-12) {
+		t.Errorf("AssignUnitQuaternion of a quarter turn is %v, want %v", m, want)
+	}
+
+	// AssignQuaternion normalizes non unit quaternions
+	scaled := quaternion.T{q[0] * 3, q[1] * 3, q[2] * 3, q[3] * 3}
+	m.AssignQuaternion(&scaled)
+	if !matEqual(&m, &want, 1e-12) {
+		t.Errorf("AssignQuaternion of a non unit quaternion is %v, want %v", m, want)
+	}
+	small := quaternion.T{q[0] * 0.01, q[1] * 0.01, q[2] * 0.01, q[3] * 0.01}
+	m.AssignQuaternion(&small)
+	if !matEqual(&m, &want, 1e-12) {
+		t.Errorf("AssignQuaternion of a short quaternion is %v, want %v", m, want)
+	}
+}
+
+func BenchmarkAssignQuaternion(b *testing.B) {
+	q := quaternion.FromEulerAngles(0.3, -1.1, 2.2)
+	var m T
+	for i := 0; i < b.N; i++ {
+		m.AssignQuaternion(&q)
+	}
+}
+
+func BenchmarkAssignUnitQuaternion(b *testing.B) {
+	q := quaternion.FromEulerAngles(0.3, -1.1, 2.2)
+	var m T
+	for i := 0; i < b.N; i++ {
+		m.AssignUnitQuaternion(&q)
+	}
+}
@@ -0,0 +1,73 @@
+package mat4
+
+import (
+	"errors"
+
+	"github.com/ungerik/go3d/float64/quaternion"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+// Stack is a transformation matrix stack like the OpenGL matrix stack.
+// All transformations are multiplied from the right to the top matrix,
+// so the last applied transformation is the first one applied to vertices.
+// Create it with NewStack.
+type Stack struct {
+	matrices []T
+}
+
+// NewStack returns a stack holding only the ident matrix.
+func NewStack() *Stack {
+	return &Stack{matrices: []T{Ident}}
+}
+
+// Top returns a pointer to the current transformation matrix at the top of the stack.
+// The pointer is only valid until the next Push.
+func (stack *Stack) Top() *T {
+	return &stack.matrices[len(stack.matrices)-1]
+}
+
+// Push pushes a copy of the top matrix onto the stack.
+func (stack *Stack) Push() *Stack {
+	stack.matrices = append(stack.matrices, *stack.Top())
+	return stack
+}
+
+// Pop removes the top matrix from the stack and restores the matrix before the last Push.
+// An error is returned if there was no matching Push,
+// the bottom matrix is never removed.
+func (stack *Stack) Pop() error {
+	if len(stack.matrices) == 1 {
+		return errors.New("mat4.Stack.Pop: stack is empty")
+	}
+	stack.matrices = stack.matrices[:len(stack.matrices)-1]
+	return nil
+}
+
+// MultMatrix multiplies the top matrix with m.
+func (stack *Stack) MultMatrix(m *T) *Stack {
+	top := stack.Top()
+	prev := *top
+	top.AssignMul(&prev, m)
+	return stack
+}
+
+// Translate multiplies the top matrix with a translation by v.
+func (stack *Stack) Translate(v *vec3.T) *Stack {
+	m := Ident
+	m.SetTranslation(v)
+	return stack.MultMatrix(&m)
+}
+
+// Rotate multiplies the top matrix with the rotation q.
+func (stack *Stack) Rotate(q *quaternion.T) *Stack {
+	var m T
+	m.AssignQuaternion(q)
+	return stack.MultMatrix(&m)
+}
+
+// Scale multiplies the top matrix with a scaling by v.
+func (stack *Stack) Scale(v *vec3.T) *Stack {
+	m := Ident
+	m.ScaleVec3(v)
+	return stack.MultMatrix(&m)
+}
@@ -0,0 +1,51 @@
+package mat4
+
+import (
+	"testing"
+
+	"github.com/ungerik/go3d/float64/quaternion"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+func TestStack(t *testing.T) {
+	stack := NewStack()
+	if *stack.Top() != Ident {
+		t.Fatalf("new stack top is %v, want ident", stack.Top())
+	}
+
+	translation := vec3.T{1, 2, 3}
+	rotation := quaternion.FromEulerAngles(0.3, -0.2, 0.5)
+	scale := vec3.T{2, 3, 4}
+	stack.Translate(&translation)
+	base := *stack.Top()
+
+	stack.Push().Rotate(&rotation).Scale(&scale)
+
+	var tm, rm, sm, trm, want T
+	tm = Ident
+	tm.SetTranslation(&translation)
+	rm.AssignQuaternion(&rotation)
+	sm = Ident
+	sm.ScaleVec3(&scale)
+	trm.AssignMul(&tm, &rm)
+	want.AssignMul(&trm, &sm)
+
+	p := vec3.T{-1, 0.5, 2}
+	got, wantP := stack.Top().MulVec3(&p), want.MulVec3(&p)
+	if !vec3Equal(&got, &wantP, EPSILON) {
+		t.Errorf("stack transforms %v to %v, want %v", p, got, wantP)
+	}
+
+	if err := stack.Pop(); err != nil {
+		t.Fatal(err)
+	}
+	if *stack.Top() != base {
+		t.Errorf("top after Pop is %v, want %v", stack.Top(), base)
+	}
+	if err := stack.Pop(); err == nil {
+		t.Errorf("Pop without Push returned no error")
+	}
+	if *stack.Top() != base {
+		t.Errorf("failed Pop changed the top to %v", stack.Top())
+	}
+}
@@ -0,0 +1,177 @@
+// Package noise contains float64 procedural noise functions.
+package noise
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+// Period is the period of the noise along every axis.
+const Period = 256
+
+var defaultPerlin = NewPerlin(0)
+
+// Perlin holds the permutation table for Perlin noise.
+// Create it with NewPerlin.
+type Perlin struct {
+	perm [2 * Period]uint8
+}
+
+// NewPerlin returns Perlin noise with a permutation table
+// shuffled by a random number generator seeded with seed.
+// The same seed always produces the same noise.
+func NewPerlin(seed int64) *Perlin {
+	p := new(Perlin)
+	rng := rand.New(rand.NewSource(seed))
+	for i, v := range rng.Perm(Period) {
+		p.perm[i] = uint8(v)
+		p.perm[i+Period] = uint8(v)
+	}
+	return p
+}
+
+// Seed replaces the permutation table used by Perlin3D and FBM.
+// It must not be called concurrently with them.
+func Seed(seed int64) {
+	defaultPerlin = NewPerlin(seed)
+}
+
+// Perlin3D returns Ken Perlin's improved gradient noise at p
+// using the permutation table set by Seed.
+// See Perlin.Noise3D
+func Perlin3D(p *vec3.T) float64 {
+	return defaultPerlin.Noise3D(p)
+}
+
+// Noise3D returns Ken Perlin's improved gradient noise at p.
+// The result is roughly in the range [-1,1], it is zero at all integer coordinates
+// and it repeats with the period Period along every axis.
+func (perlin *Perlin) Noise3D(p *vec3.T) float64 {
+	fx, fy, fz := math.Floor(p[0]), math.Floor(p[1]), math.Floor(p[2])
+	x, y, z := p[0]-fx, p[1]-fy, p[2]-fz
+	// wrap the integer coordinates into the period
+	xi := int(int64(fx) & (Period - 1))
+	yi := int(int64(fy) & (Period - 1))
+	zi := int(int64(fz) & (Period - 1))
+
+	u, v, w := fade(x), fade(y), fade(z)
+
+	perm := &perlin.perm
+	a := int(perm[xi]) + yi
+	aa := int(perm[a]) + zi
+	ab := int(perm[a+1]) + zi
+	b := int(perm[xi+1]) + yi
+	ba := int(perm[b]) + zi
+	bb := int(perm[b+1]) + zi
+
+	return lerp(w,
+		lerp(v,
+			lerp(u, grad(perm[aa], x, y, z), grad(perm[ba], x-1, y, z)),
+			lerp(u, grad(perm[ab], x, y-1, z), grad(perm[bb], x-1, y-1, z)),
+		),
+		lerp(v,
+			lerp(u, grad(perm[aa+1], x, y, z-1), grad(perm[ba+1], x-1, y, z-1)),
+			lerp(u, grad(perm[ab+1], x, y-1, z-1), grad(perm[bb+1], x-1, y-1, z-1)),
+		),
+	)
+}
+
+// fade is the quintic interpolation curve 6t⁵-15t⁴+10t³
+// with zero first and second derivatives at 0 and 1.
+func fade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+func lerp(t, a, b float64) float64 {
+	return a + t*(b-a)
+}
+
+// grad returns the dot product of x, y, z with one of
+// the 12 gradient directions to the edges of a cube selected by hash.
+func grad(hash uint8, x, y, z float64) float64 {
+	h := hash & 15
+	u := y
+	if h < 8 {
+		u = x
+	}
+	var v float64
+	switch {
+	case h < 4:
+		v = y
+	case h == 12 || h == 14:
+		v = x
+	default:
+		v = z
+	}
+	if h&1 != 0 {
+		u = -u
+	}
+	if h&2 != 0 {
+		v = -v
+	}
+	return u + v
+}
+
+// FBM returns fractal Brownian motion noise at p by summing octaves of Perlin3D
+// using the permutation table set by Seed.
+// See Perlin.FBM
+func FBM(p *vec3.T, octaves int, lacunarity, gain float64) float64 {
+	return defaultPerlin.FBM(p, octaves, lacunarity, gain)
+}
+
+// FBM returns fractal Brownian motion noise at p by summing octaves of Noise3D.
+// Every octave multiplies the frequency by lacunarity (typically 2)
+// and the amplitude by gain (typically 0.5), starting with frequency and amplitude 1.
+// The result is not normalized, it is roughly in the range ±(1 + gain + gain² + ...),
+// which is below ±1/(1-gain) for a gain smaller than 1.
+func (perlin *Perlin) FBM(p *vec3.T, octaves int, lacunarity, gain float64) float64 {
+	sum := 0.0
+	frequency := 1.0
+	amplitude := 1.0
+	for i := 0; i < octaves; i++ {
+		q := p.Scaled(frequency)
+		sum += perlin.Noise3D(&q) * amplitude
+		frequency *= lacunarity
+		amplitude *= gain
+	}
+	return sum
+}
+
+// CurlNoise returns the curl of a vector potential made of three Perlin3D noise fields
+// using the permutation table set by Seed.
+// See Perlin.CurlNoise
+func CurlNoise(p *vec3.T) vec3.T {
+	return defaultPerlin.CurlNoise(p)
+}
+
+// curlOffsets shift the samples of the three potential components
+// far enough apart to make them uncorrelated.
+var curlOffsets = [3]vec3.T{{0, 0, 0}, {31.416, -47.853, 12.793}, {-71.337, 23.179, 58.551}}
+
+// CurlNoise returns the curl of a vector potential whose three components are
+// Noise3D sampled at p shifted by different constant offsets.
+// The partial derivatives are computed with central differences.
+// As the curl of a field, the result is divergence free,
+// which makes it useful as velocity field for particles
+// that move like an incompressible fluid.
+func (perlin *Perlin) CurlNoise(p *vec3.T) vec3.T {
+	const h = 1e-4
+	// d[i][j] is the partial derivative of the potential component i along axis j
+	var d [3][3]float64
+	for i := range d {
+		for j := range d[i] {
+			a := vec3.Add(p, &curlOffsets[i])
+			b := a
+			a[j] += h
+			b[j] -= h
+			d[i][j] = (perlin.Noise3D(&a) - perlin.Noise3D(&b)) / (2 * h)
+		}
+	}
+	return vec3.T{
+		d[2][1] - d[1][2],
+		d[0][2] - d[2][0],
+		d[1][0] - d[0][1],
+	}
+}
@@ -0,0 +1,135 @@
+package noise
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+func TestPerlinDeterminism(t *testing.T) {
+	a := NewPerlin(42)
+	b := NewPerlin(42)
+	c := NewPerlin(43)
+	differs := false
+	for i := 0; i < 100; i++ {
+		p := vec3.T{float64(i) * 0.37, float64(i) * 0.11, float64(i) * -0.23}
+		if a.Noise3D(&p) != b.Noise3D(&p) {
+			t.Fatalf("same seed gives different noise at %v", p)
+		}
+		if a.Noise3D(&p) != c.Noise3D(&p) {
+			differs = true
+		}
+	}
+	if !differs {
+		t.Error("different seeds give the same noise")
+	}
+}
+
+func TestPerlinRange(t *testing.T) {
+	perlin := NewPerlin(1)
+	sum := 0.0
+	n := 0
+	for x := 0; x < 20; x++ {
+		for y := 0; y < 20; y++ {
+			for z := 0; z < 20; z++ {
+				p := vec3.T{float64(x)*0.31 + 0.05, float64(y)*0.29 + 0.13, float64(z)*0.37 + 0.21}
+				v := perlin.Noise3D(&p)
+				if v < -1.1 || v > 1.1 {
+					t.Fatalf("noise at %v is %v, out of range", p, v)
+				}
+				sum += v
+				n++
+			}
+		}
+	}
+	if mean := sum / float64(n); math.Abs(mean) > 0.05 {
+		t.Errorf("mean of the noise is %v, want about 0", mean)
+	}
+
+	lattice := vec3.T{3, -7, 12}
+	if v := perlin.Noise3D(&lattice); v != 0 {
+		t.Errorf("noise at the integer lattice point %v is %v, want 0", lattice, v)
+	}
+	shifted := vec3.T{0.3 + Period, 0.6, -0.2 - Period}
+	base := vec3.T{0.3, 0.6, -0.2}
+	if a, b := perlin.Noise3D(&shifted), perlin.Noise3D(&base); math.Abs(a-b) > 1e-9 {
+		t.Errorf("noise is not periodic: %v != %v", a, b)
+	}
+}
+
+func TestPerlinContinuity(t *testing.T) {
+	perlin := NewPerlin(2)
+	const d = 1e-7
+	for _, p := range []vec3.T{{1, 0.3, 0.7}, {0.4, 2, 0.1}, {0.5, 0.5, -3}, {5, 5, 5}} {
+		below := vec3.T{p[0] - d, p[1] - d, p[2] - d}
+		above := vec3.T{p[0] + d, p[1] + d, p[2] + d}
+		if diff := math.Abs(perlin.Noise3D(&above) - perlin.Noise3D(&below)); diff > 1e-5 {
+			t.Errorf("noise jumps by %v across the cell boundary at %v", diff, p)
+		}
+	}
+}
+
+func TestFBM(t *testing.T) {
+	perlin := NewPerlin(3)
+	const gain = 0.5
+	roughness := func(octaves int) float64 {
+		// mean squared difference of neighboring samples measures high frequency detail
+		sum := 0.0
+		prev := 0.0
+		for i := 0; i <= 2000; i++ {
+			p := vec3.T{float64(i) * 0.01, 0.37, 0.71}
+			v := perlin.FBM(&p, octaves, 2, gain)
+			if bound := 1.1 * (1 - math.Pow(gain, float64(octaves))) / (1 - gain); math.Abs(v) > bound {
+				t.Fatalf("FBM with %d octaves at %v is %v, out of the bound %v", octaves, p, v, bound)
+			}
+			if i > 0 {
+				sum += (v - prev) * (v - prev)
+			}
+			prev = v
+		}
+		return sum
+	}
+	r1, r4, r8 := roughness(1), roughness(4), roughness(8)
+	if !(r1 < r4 && r4 < r8) {
+		t.Errorf("more octaves must add detail, roughness is %v, %v, %v for 1, 4, 8 octaves", r1, r4, r8)
+	}
+
+	p := vec3.T{0.3, 0.4, 0.5}
+	if a, b := perlin.FBM(&p, 1, 2, gain), perlin.Noise3D(&p); a != b {
+		t.Errorf("FBM with one octave is %v, want %v", a, b)
+	}
+}
+
+func TestCurlNoise(t *testing.T) {
+	perlin := NewPerlin(7)
+	other := NewPerlin(7)
+	const h = 1e-3
+	maxLength := 0.0
+	for i := 0; i < 200; i++ {
+		p := vec3.T{float64(i)*0.173 + 0.5, float64(i)*-0.091 + 0.25, float64(i) * 0.057}
+		c := perlin.CurlNoise(&p)
+		if c != other.CurlNoise(&p) {
+			t.Fatalf("curl noise at %v is not deterministic", p)
+		}
+		maxLength = math.Max(maxLength, c.Length())
+
+		divergence := 0.0
+		for axis := 0; axis < 3; axis++ {
+			a, b := p, p
+			a[axis] += h
+			b[axis] -= h
+			ca, cb := perlin.CurlNoise(&a), perlin.CurlNoise(&b)
+			divergence += (ca[axis] - cb[axis]) / (2 * h)
+		}
+		if math.Abs(divergence) > 1e-2 {
+			t.Errorf("divergence at %v is %v, want 0", p, divergence)
+		}
+	}
+	if maxLength > 10 {
+		t.Errorf("curl noise length is up to %v, want a bounded field", maxLength)
+	}
+	if maxLength == 0 {
+		t.Errorf("curl noise is zero")
+	}
+}
@@ -0,0 +1,270 @@
+// Package obb3 contains a float64 3D oriented bounding box type T and functions.
+package obb3
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ungerik/go3d/float64/mat3"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+// T represents an oriented bounding box with the Center,
+// the orthonormal right-handed box axes as columns of Axes
+// and the half edge lengths along these axes in HalfExtents.
+type T struct {
+	Center      vec3.T
+	Axes        mat3.T
+	HalfExtents vec3.T
+}
+
+// FromBox returns the oriented bounding box equal to the axis aligned box.
+func FromBox(box *vec3.Box) T {
+	half := box.Diagonal()
+	return T{
+		Center:      box.Center(),
+		Axes:        mat3.Ident,
+		HalfExtents: half.Scaled(0.5),
+	}
+}
+
+// Parse parses T from a string. See also String()
+func Parse(s string) (r T, err error) {
+	_, err = fmt.Sscan(s,
+		&r.Center[0], &r.Center[1], &r.Center[2],
+		&r.Axes[0][0], &r.Axes[0][1], &r.Axes[0][2],
+		&r.Axes[1][0], &r.Axes[1][1], &r.Axes[1][2],
+		&r.Axes[2][0], &r.Axes[2][1], &r.Axes[2][2],
+		&r.HalfExtents[0], &r.HalfExtents[1], &r.HalfExtents[2],
+	)
+	return r, err
+}
+
+// String formats T as string. See also Parse().
+func (obb *T) String() string {
+	return obb.Center.String() + " " + obb.Axes.String() + " " + obb.HalfExtents.String()
+}
+
+// FitToPoints returns an oriented bounding box containing all points.
+// The axes are the principal components of the points, the eigenvectors
+// of their covariance matrix, which aligns the box with elongated point clouds.
+// The result is usually tight, but not guaranteed to be the minimal box.
+// An empty slice returns a zero box at the origin.
+func FitToPoints(points []vec3.T) T {
+	if len(points) == 0 {
+		return T{Axes: mat3.Ident}
+	}
+	var mean vec3.T
+	for i := range points {
+		mean.Add(&points[i])
+	}
+	mean.Scale(1 / float64(len(points)))
+
+	var cov mat3.T
+	for i := range points {
+		d := vec3.Sub(&points[i], &mean)
+		for col := 0; col < 3; col++ {
+			for row := 0; row < 3; row++ {
+				cov[col][row] += d[col] * d[row]
+			}
+		}
+	}
+	_, axes := cov.SymmetricEigen()
+	// make the axes right-handed
+	axes[2] = vec3.Cross(&axes[0], &axes[1])
+
+	// extents of the points projected onto the axes
+	min := vec3.MaxVal
+	max := vec3.MinVal
+	for i := range points {
+		for a := 0; a < 3; a++ {
+			p := vec3.Dot(&points[i], &axes[a])
+			min[a] = math.Min(min[a], p)
+			max[a] = math.Max(max[a], p)
+		}
+	}
+	var obb T
+	obb.Axes = axes
+	for a := 0; a < 3; a++ {
+		obb.HalfExtents[a] = (max[a] - min[a]) * 0.5
+		c := axes[a].Scaled((max[a] + min[a]) * 0.5)
+		obb.Center.Add(&c)
+	}
+	return obb
+}
+
+// Volume returns the volume of the box.
+func (obb *T) Volume() float64 {
+	return 8 * obb.HalfExtents[0] * obb.HalfExtents[1] * obb.HalfExtents[2]
+}
+
+// ContainsPoint returns if a point is contained within the box.
+func (obb *T) ContainsPoint(p *vec3.T) bool {
+	d := vec3.Sub(p, &obb.Center)
+	for a := 0; a < 3; a++ {
+		if math.Abs(vec3.Dot(&d, &obb.Axes[a])) > obb.HalfExtents[a] {
+			return false
+		}
+	}
+	return true
+}
+
+// IntersectsOBB returns true if this and the other box intersect, including touching.
+// It uses the separating axis test of Gottschalk with the 15 candidate axes:
+// the three face normals of each box and the nine cross products of their axes.
+func (obb *T) IntersectsOBB(other *T) bool {
+	const epsilon = 1e-12
+	a, b := &obb.HalfExtents, &other.HalfExtents
+	// rotation expressing other in the coordinate frame of obb
+	var r, absR [3][3]float64
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			r[i][j] = vec3.Dot(&obb.Axes[i], &other.Axes[j])
+			// the epsilon counters arithmetic errors for nearly parallel edges
+			absR[i][j] = math.Abs(r[i][j]) + epsilon
+		}
+	}
+	d := vec3.Sub(&other.Center, &obb.Center)
+	t := vec3.T{vec3.Dot(&d, &obb.Axes[0]), vec3.Dot(&d, &obb.Axes[1]), vec3.Dot(&d, &obb.Axes[2])}
+
+	// face normals of obb
+	for i := 0; i < 3; i++ {
+		rb := b[0]*absR[i][0] + b[1]*absR[i][1] + b[2]*absR[i][2]
+		if math.Abs(t[i]) > a[i]+rb {
+			return false
+		}
+	}
+	// face normals of other
+	for j := 0; j < 3; j++ {
+		ra := a[0]*absR[0][j] + a[1]*absR[1][j] + a[2]*absR[2][j]
+		if math.Abs(t[0]*r[0][j]+t[1]*r[1][j]+t[2]*r[2][j]) > ra+b[j] {
+			return false
+		}
+	}
+	// cross products of the edges
+	for i := 0; i < 3; i++ {
+		i1, i2 := (i+1)%3, (i+2)%3
+		for j := 0; j < 3; j++ {
+			j1, j2 := (j+1)%3, (j+2)%3
+			ra := a[i1]*absR[i2][j] + a[i2]*absR[i1][j]
+			rb := b[j1]*absR[i][j2] + b[j2]*absR[i][j1]
+			if math.Abs(t[i2]*r[i1][j]-t[i1]*r[i2][j]) > ra+rb {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// BoxBoxContact returns the contact of two oriented boxes given by their centers,
+// their orthonormal axes as columns of aAxes and bAxes and their half extents.
+// It tests the same 15 separating axis candidates as IntersectsOBB and returns
+// the axis of minimum penetration as the unit normal pointing from box a to box b,
+// the penetration depth along it and a contact point halfway between the
+// touching features. Face axes are preferred over nearly equally penetrating
+// edge axes for stable resting contacts.
+// If the boxes do not overlap, colliding is false and the other results are zero.
+func BoxBoxContact(aCenter *vec3.T, aAxes *mat3.T, aHalf *vec3.T, bCenter *vec3.T, bAxes *mat3.T, bHalf *vec3.T) (normal vec3.T, penetration float64, contact vec3.T, colliding bool) {
+	const edgeBias = 0.95
+	d := vec3.Sub(bCenter, aCenter)
+	radius := func(axes *mat3.T, half *vec3.T, axis *vec3.T) float64 {
+		return half[0]*math.Abs(vec3.Dot(&axes[0], axis)) +
+			half[1]*math.Abs(vec3.Dot(&axes[1], axis)) +
+			half[2]*math.Abs(vec3.Dot(&axes[2], axis))
+	}
+	penetration = math.Inf(1)
+	// best is 0 to 2 for a face of a, 3 to 5 for a face of b
+	// and 6 + 3*i + j for the edge axes aAxes[i] x bAxes[j]
+	best := -1
+	test := func(axis vec3.T, index int) bool {
+		overlap := radius(aAxes, aHalf, &axis) + radius(bAxes, bHalf, &axis) - math.Abs(vec3.Dot(&d, &axis))
+		if overlap < 0 {
+			return false
+		}
+		if index < 6 && overlap < penetration || index >= 6 && overlap < edgeBias*penetration {
+			if vec3.Dot(&d, &axis) < 0 {
+				axis.Invert()
+			}
+			normal, penetration, best = axis, overlap, index
+		}
+		return true
+	}
+	for i := 0; i < 3; i++ {
+		if !test(aAxes[i], i) {
+			return vec3.T{}, 0, vec3.T{}, false
+		}
+	}
+	for j := 0; j < 3; j++ {
+		if !test(bAxes[j], 3+j) {
+			return vec3.T{}, 0, vec3.T{}, false
+		}
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			axis := vec3.Cross(&aAxes[i], &bAxes[j])
+			if axis.LengthSqr() < 1e-12 {
+				// parallel edges, already covered by the face normals
+				continue
+			}
+			axis.Normalize()
+			if !test(axis, 6+3*i+j) {
+				return vec3.T{}, 0, vec3.T{}, false
+			}
+		}
+	}
+
+	// support returns the center of the feature of the box that reaches furthest along dir,
+	// the box axis skip is kept at the center to get the center of an edge along it
+	support := func(center *vec3.T, axes *mat3.T, half *vec3.T, dir *vec3.T, skip int) vec3.T {
+		p := *center
+		for k := 0; k < 3; k++ {
+			dot := vec3.Dot(&axes[k], dir)
+			if k == skip || math.Abs(dot) < 1e-9 {
+				continue
+			}
+			offset := axes[k].Scaled(math.Copysign(half[k], dot))
+			p.Add(&offset)
+		}
+		return p
+	}
+	// clampToFace clamps p to the extents of the box along the axes other than the face axis
+	clampToFace := func(p *vec3.T, center *vec3.T, axes *mat3.T, half *vec3.T, face int) vec3.T {
+		rel := vec3.Sub(p, center)
+		result := *p
+		for k := 0; k < 3; k++ {
+			if k == face {
+				continue
+			}
+			dot := vec3.Dot(&rel, &axes[k])
+			clamped := math.Max(-half[k], math.Min(dot, half[k]))
+			offset := axes[k].Scaled(clamped - dot)
+			result.Add(&offset)
+		}
+		return result
+	}
+	halfway := normal.Scaled(penetration / 2)
+	inverted := normal.Inverted()
+	switch {
+	case best < 3:
+		// the feature of b touching the face of a
+		p := support(bCenter, bAxes, bHalf, &inverted, -1)
+		contact = clampToFace(&p, aCenter, aAxes, aHalf, best)
+		contact.Add(&halfway)
+	case best < 6:
+		// the feature of a touching the face of b
+		p := support(aCenter, aAxes, aHalf, &normal, -1)
+		contact = clampToFace(&p, bCenter, bAxes, bHalf, best-3)
+		contact.Sub(&halfway)
+	default:
+		i, j := (best-6)/3, (best-6)%3
+		ca := support(aCenter, aAxes, aHalf, &normal, i)
+		cb := support(bCenter, bAxes, bHalf, &inverted, j)
+		ea := aAxes[i].Scaled(aHalf[i])
+		eb := bAxes[j].Scaled(bHalf[j])
+		a0, a1 := vec3.Sub(&ca, &ea), vec3.Add(&ca, &ea)
+		b0, b1 := vec3.Sub(&cb, &eb), vec3.Add(&cb, &eb)
+		pa, pb := vec3.ClosestPointsOnSegments(&a0, &a1, &b0, &b1)
+		contact = vec3.Interpolate(&pa, &pb, 0.5)
+	}
+	return normal, penetration, contact, true
+}
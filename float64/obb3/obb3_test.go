@@ -0,0 +1,118 @@
+package obb3
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/mat3"
+	"github.com/ungerik/go3d/float64/quaternion"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+func TestFitToPoints(t *testing.T) {
+	q := quaternion.FromEulerAngles(0.6, 0.4, -0.9)
+	var rot mat3.T
+	rot.AssignQuaternion(&q)
+	rng := rand.New(rand.NewSource(7))
+	points := make([]vec3.T, 1000)
+	box := vec3.Box{Min: vec3.MaxVal, Max: vec3.MinVal}
+	for i := range points {
+		local := vec3.T{(rng.Float64()*2 - 1) * 10, (rng.Float64()*2 - 1) * 2, (rng.Float64()*2 - 1) * 0.5}
+		points[i] = rot.MulVec3(&local)
+		points[i].Add(&vec3.T{5, -3, 1})
+		box.Min = vec3.Min(&box.Min, &points[i])
+		box.Max = vec3.Max(&box.Max, &points[i])
+	}
+
+	obb := FitToPoints(points)
+	for i := range points {
+		// allow for rounding of the projections
+		grown := obb
+		grown.HalfExtents.Add(&vec3.T{1e-9, 1e-9, 1e-9})
+		if !grown.ContainsPoint(&points[i]) {
+			t.Fatalf("point %v is not contained in %v", points[i], obb.String())
+		}
+	}
+	diagonal := box.Diagonal()
+	aabbVolume := diagonal[0] * diagonal[1] * diagonal[2]
+	if obb.Volume() >= aabbVolume*0.5 {
+		t.Errorf("OBB volume %v is not much tighter than the AABB volume %v", obb.Volume(), aabbVolume)
+	}
+	// the longest axis follows the rotated X axis
+	if d := math.Abs(vec3.Dot(&obb.Axes[0], &rot[0])); d < 0.99 {
+		t.Errorf("principal axis %v is not aligned with %v", obb.Axes[0], rot[0])
+	}
+}
+
+func TestIntersectsOBB(t *testing.T) {
+	a := T{Center: vec3.Zero, Axes: mat3.Ident, HalfExtents: vec3.T{1, 1, 1}}
+
+	q := quaternion.FromZAxisAngle(math.Pi / 4)
+	var rot mat3.T
+	rot.AssignQuaternion(&q)
+	b := T{Center: vec3.T{2.3, 0, 0}, Axes: rot, HalfExtents: vec3.T{1, 1, 1}}
+	// the rotated box reaches sqrt(2) towards a
+	if !a.IntersectsOBB(&b) || !b.IntersectsOBB(&a) {
+		t.Error("rotated box with its corner inside must intersect")
+	}
+	b.Center = vec3.T{2.5, 0, 0}
+	if a.IntersectsOBB(&b) || b.IntersectsOBB(&a) {
+		t.Error("rotated box beyond the corner distance must not intersect")
+	}
+
+	q = quaternion.FromEulerAngles(math.Pi/4, 0, math.Pi/4)
+	rot.AssignQuaternion(&q)
+	c := T{Center: vec3.T{1, 1, 0}, Axes: rot, HalfExtents: vec3.T{1, 1, 1}}
+	if !a.IntersectsOBB(&c) {
+		t.Error("overlapping boxes must intersect")
+	}
+	far := c
+	far.Center = vec3.T{5, 5, 5}
+	if a.IntersectsOBB(&far) {
+		t.Error("far away boxes must not intersect")
+	}
+}
+
+func TestBoxBoxContact(t *testing.T) {
+	half := vec3.T{1, 1, 1}
+	check := func(name string, aCenter *vec3.T, aAxes *mat3.T, bCenter *vec3.T, bAxes *mat3.T, wantNormal vec3.T, wantDepth float64, wantContact vec3.T) {
+		normal, depth, contact, colliding := BoxBoxContact(aCenter, aAxes, &half, bCenter, bAxes, &half)
+		if !colliding {
+			t.Errorf("%s: boxes are not colliding", name)
+			return
+		}
+		if vec3.Distance(&normal, &wantNormal) > 1e-9 {
+			t.Errorf("%s: normal is %v, want %v", name, normal, wantNormal)
+		}
+		if math.Abs(depth-wantDepth) > 1e-9 {
+			t.Errorf("%s: penetration is %v, want %v", name, depth, wantDepth)
+		}
+		if vec3.Distance(&contact, &wantContact) > 1e-9 {
+			t.Errorf("%s: contact is %v, want %v", name, contact, wantContact)
+		}
+	}
+
+	// face-face: b overlaps the +X face of a by 0.2
+	check("face-face", &vec3.Zero, &mat3.Ident, &vec3.T{1.8, 0.5, 0}, &mat3.Ident,
+		vec3.UnitX, 0.2, vec3.T{0.9, 0.5, 0})
+
+	// edge-edge: the top edge of a along X crosses the bottom edge of b along Z
+	s := math.Sqrt(0.5)
+	aboutX := mat3.T{vec3.T{1, 0, 0}, vec3.T{0, s, s}, vec3.T{0, -s, s}}
+	aboutZ := mat3.T{vec3.T{s, s, 0}, vec3.T{-s, s, 0}, vec3.T{0, 0, 1}}
+	top := math.Sqrt2
+	bCenter := vec3.T{0, 2*top - 0.1, 0}
+	check("edge-edge", &vec3.Zero, &aboutX, &bCenter, &aboutZ,
+		vec3.UnitY, 0.1, vec3.T{0, top - 0.05, 0})
+
+	// the normal points from a to b
+	normal, _, _, _ := BoxBoxContact(&vec3.T{1.8, 0.5, 0}, &mat3.Ident, &half, &vec3.Zero, &mat3.Ident, &half)
+	if want := vec3.UnitX.Inverted(); normal != want {
+		t.Errorf("swapped face-face normal is %v, want %v", normal, want)
+	}
+
+	if _, _, _, colliding := BoxBoxContact(&vec3.Zero, &mat3.Ident, &half, &vec3.T{2.1, 0, 0}, &mat3.Ident, &half); colliding {
+		t.Error("separated boxes are colliding")
+	}
+}
@@ -0,0 +1,48 @@
+// Package physics contains float64 rigid body helper functions.
+package physics
+
+import (
+	"errors"
+
+	"github.com/ungerik/go3d/float64/mat3"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+// InertiaTensor returns the inertia tensor of the point masses at points
+// with the masses masses about their center of mass:
+// the sum of mass * (|r|² * Ident - r*rᵀ) with r being the position relative to the center of mass.
+// An error is returned if the number of points and masses differ
+// or if the total mass is not positive.
+func InertiaTensor(points []vec3.T, masses []float64) (mat3.T, error) {
+	if len(points) != len(masses) {
+		return mat3.Zero, errors.New("physics.InertiaTensor: number of points and masses differ")
+	}
+	var totalMass float64
+	var center vec3.T
+	for i := range points {
+		p := points[i].Scaled(masses[i])
+		center.Add(&p)
+		totalMass += masses[i]
+	}
+	if totalMass <= 0 {
+		return mat3.Zero, errors.New("physics.InertiaTensor: total mass must be positive")
+	}
+	center.Scale(1 / totalMass)
+
+	var tensor mat3.T
+	for i := range points {
+		r := vec3.Sub(&points[i], &center)
+		m := masses[i]
+		rr := r.LengthSqr()
+		for col := 0; col < 3; col++ {
+			for row := 0; row < 3; row++ {
+				v := -r[col] * r[row]
+				if col == row {
+					v += rr
+				}
+				tensor[col][row] += m * v
+			}
+		}
+	}
+	return tensor, nil
+}
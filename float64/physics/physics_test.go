@@ -0,0 +1,37 @@
+package physics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/mat3"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+func TestInertiaTensor(t *testing.T) {
+	// two unit masses at ±1 on each axis, shifted away from the origin
+	offset := vec3.T{3, -2, 5}
+	var points []vec3.T
+	var masses []float64
+	for _, p := range []vec3.T{{1, 0, 0}, {-1, 0, 0}, {0, 2, 0}, {0, -2, 0}, {0, 0, 3}, {0, 0, -3}} {
+		points = append(points, vec3.Add(&p, &offset))
+		masses = append(masses, 1)
+	}
+	tensor, err := InertiaTensor(points, masses)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Ixx = sum of m*(y²+z²) = 2*4 + 2*9
+	want := mat3.T{vec3.T{26, 0, 0}, vec3.T{0, 20, 0}, vec3.T{0, 0, 10}}
+	for col := range want {
+		for row := range want[col] {
+			if math.Abs(tensor[col][row]-want[col][row]) > 1e-12 {
+				t.Fatalf("inertia tensor is %v, want %v", tensor, want)
+			}
+		}
+	}
+
+	if _, err := InertiaTensor(points, masses[:2]); err == nil {
+		t.Error("mismatched lengths must fail")
+	}
+}
@@ -0,0 +1,169 @@
+// Package plane contains a float64 3D plane type T and functions.
+package plane
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ungerik/go3d/float64/mat3"
+	"github.com/ungerik/go3d/float64/vec3"
+	"github.com/ungerik/go3d/float64/vec4"
+)
+
+// Classification results of Classify and ClassifyPolygon.
+const (
+	// Back means behind the plane, on the side opposite to the normal.
+	Back = -1
+	// Coplanar means on the plane within the tolerance.
+	Coplanar = 0
+	// Front means in front of the plane, on the side the normal points to.
+	Front = 1
+	// Spanning means a polygon has vertices in front of and behind the plane.
+	Spanning = 2
+)
+
+// T represents a plane as unit length Normal and the offset D,
+// so that all points p on the plane satisfy vec3.Dot(&Normal, p) + D == 0.
+type T struct {
+	Normal vec3.T
+	D      float64
+}
+
+// FromPointNormal returns the plane through point with the given normal.
+// The normal will be normalized.
+func FromPointNormal(point, normal *vec3.T) T {
+	n := normal.Normalized()
+	return T{Normal: n, D: -vec3.Dot(&n, point)}
+}
+
+// Parse parses T from a string. See also String()
+func Parse(s string) (r T, err error) {
+	_, err = fmt.Sscan(s, &r.Normal[0], &r.Normal[1], &r.Normal[2], &r.D)
+	return r, err
+}
+
+// String formats T as string. See also Parse().
+func (plane *T) String() string {
+	return fmt.Sprint(plane.Normal[0], plane.Normal[1], plane.Normal[2], plane.D)
+}
+
+// Vec4 returns the coefficients of the plane equation as vec4.T.
+func (plane *T) Vec4() vec4.T {
+	return vec4.T{plane.Normal[0], plane.Normal[1], plane.Normal[2], plane.D}
+}
+
+// SignedDistance returns the signed distance of p from the plane,
+// positive on the side the normal points to.
+func (plane *T) SignedDistance(p *vec3.T) float64 {
+	return vec3.Dot(&plane.Normal, p) + plane.D
+}
+
+// Classify returns Front (+1) if p is in front of the plane, Back (-1) if it is behind it,
+// or Coplanar (0) if its distance from the plane is at most epsilon.
+func (plane *T) Classify(p *vec3.T, epsilon float64) int {
+	d := plane.SignedDistance(p)
+	switch {
+	case d > epsilon:
+		return Front
+	case d < -epsilon:
+		return Back
+	default:
+		return Coplanar
+	}
+}
+
+// ClassifyPolygon classifies the polygon with the vertices points relative to the plane.
+// It returns Coplanar if all vertices are coplanar, Front or Back if all vertices
+// are in front of or behind the plane or coplanar, and Spanning if there are
+// vertices on both sides, in which case the polygon has to be split for BSP construction.
+func (plane *T) ClassifyPolygon(points []vec3.T, epsilon float64) int {
+	front, back := false, false
+	for i := range points {
+		switch plane.Classify(&points[i], epsilon) {
+		case Front:
+			front = true
+		case Back:
+			back = true
+		}
+	}
+	switch {
+	case front && back:
+		return Spanning
+	case front:
+		return Front
+	case back:
+		return Back
+	default:
+		return Coplanar
+	}
+}
+
+// Point returns the point of the plane that is closest to the origin.
+func (plane *T) Point() vec3.T {
+	return plane.Normal.Scaled(-plane.D)
+}
+
+// FitToPoints returns the best fitting plane through points in the least squares sense.
+// The plane passes through the centroid of the points and its normal is the eigenvector
+// with the smallest eigenvalue of the covariance matrix of the points.
+// The orientation of the normal is arbitrary.
+// An error is returned for less than 3 points or if all points lie on a line.
+func FitToPoints(points []vec3.T) (T, error) {
+	if len(points) < 3 {
+		return T{}, errors.New("plane.FitToPoints: at least 3 points required")
+	}
+	var centroid vec3.T
+	for i := range points {
+		centroid.Add(&points[i])
+	}
+	centroid.Scale(1 / float64(len(points)))
+
+	var cov mat3.T
+	for i := range points {
+		d := vec3.Sub(&points[i], &centroid)
+		for col := 0; col < 3; col++ {
+			for row := 0; row < 3; row++ {
+				cov[col][row] += d[col] * d[row]
+			}
+		}
+	}
+	values, vectors := cov.SymmetricEigen()
+	if values[1] <= values[0]*1e-12 {
+		return T{}, errors.New("plane.FitToPoints: points are collinear")
+	}
+	return FromPointNormal(&centroid, &vectors[2]), nil
+}
+
+// SplitPolygon clips the convex polygon with the vertices points against the plane p
+// and returns the parts in front of and behind the plane.
+// Intersection vertices are inserted where edges cross the plane,
+// vertices within epsilon of the plane are added to both parts.
+// A polygon that is not spanning the plane is returned unchanged
+// as front or back part, coplanar polygons are returned as front part.
+func SplitPolygon(points []vec3.T, p *T, epsilon float64) (front, back []vec3.T) {
+	switch p.ClassifyPolygon(points, epsilon) {
+	case Front, Coplanar:
+		return append([]vec3.T(nil), points...), nil
+	case Back:
+		return nil, append([]vec3.T(nil), points...)
+	}
+	for i := range points {
+		j := (i + 1) % len(points)
+		ci := p.Classify(&points[i], epsilon)
+		cj := p.Classify(&points[j], epsilon)
+		if ci != Back {
+			front = append(front, points[i])
+		}
+		if ci != Front {
+			back = append(back, points[i])
+		}
+		if ci*cj < 0 {
+			di := p.SignedDistance(&points[i])
+			dj := p.SignedDistance(&points[j])
+			v := vec3.Interpolate(&points[i], &points[j], di/(di-dj))
+			front = append(front, v)
+			back = append(back, v)
+		}
+	}
+	return front, back
+}
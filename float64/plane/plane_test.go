@@ -0,0 +1,115 @@
+package plane
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+const EPSILON = 0.000001
+
+func TestSignedDistance(t *testing.T) {
+	p := FromPointNormal(&vec3.T{0, 2, 0}, &vec3.T{0, 3, 0})
+	if d := p.SignedDistance(&vec3.T{5, 5, 5}); math.Abs(d-3) > EPSILON {
+		t.Errorf("signed distance is %v, want 3", d)
+	}
+	if d := p.SignedDistance(&vec3.T{1, -1, 0}); math.Abs(d+3) > EPSILON {
+		t.Errorf("signed distance is %v, want -3", d)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	p := FromPointNormal(&vec3.Zero, &vec3.UnitY)
+	if c := p.Classify(&vec3.T{3, 0.5, -1}, EPSILON); c != Front {
+		t.Errorf("point above the plane is classified %d, want Front", c)
+	}
+	if c := p.Classify(&vec3.T{3, -0.5, -1}, EPSILON); c != Back {
+		t.Errorf("point below the plane is classified %d, want Back", c)
+	}
+	if c := p.Classify(&vec3.T{3, 1e-9, -1}, EPSILON); c != Coplanar {
+		t.Errorf("point within epsilon is classified %d, want Coplanar", c)
+	}
+
+	polygons := []struct {
+		points []vec3.T
+		want   int
+	}{
+		{[]vec3.T{{0, 0, 0}, {1, 0, 0}, {0, 0, 1}}, Coplanar},
+		{[]vec3.T{{0, 1, 0}, {1, 2, 0}, {0, 0, 1}}, Front},
+		{[]vec3.T{{0, -1, 0}, {1, -2, 0}, {0, 0, 1}}, Back},
+		{[]vec3.T{{0, -1, 0}, {1, 1, 0}, {0, 1, 1}}, Spanning},
+	}
+	for _, poly := range polygons {
+		if c := p.ClassifyPolygon(poly.points, EPSILON); c != poly.want {
+			t.Errorf("polygon %v is classified %d, want %d", poly.points, c, poly.want)
+		}
+	}
+}
+
+func TestFitToPoints(t *testing.T) {
+	normal := vec3.T{1, 2, 2}
+	normal.Normalize()
+	origin := vec3.T{1, 1, 1}
+	u := normal.Normal()
+	v := vec3.Cross(&normal, &u)
+
+	var points []vec3.T
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 10; j++ {
+			noise := 0.001 * math.Sin(float64(i*7+j*13))
+			pu := u.Scaled(float64(i) - 4.5)
+			pv := v.Scaled(float64(j) - 4.5)
+			pn := normal.Scaled(noise)
+			p := vec3.Add(&origin, &pu)
+			p.Add(&pv).Add(&pn)
+			points = append(points, p)
+		}
+	}
+	plane, err := FitToPoints(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := math.Abs(vec3.Dot(&plane.Normal, &normal)); math.Abs(d-1) > 0.0001 {
+		t.Errorf("fitted normal %v differs from %v", plane.Normal, normal)
+	}
+	if d := plane.SignedDistance(&origin); math.Abs(d) > 0.001 {
+		t.Errorf("fitted plane is %v away from the origin point", d)
+	}
+
+	if _, err := FitToPoints(points[:2]); err == nil {
+		t.Error("expected an error for less than 3 points")
+	}
+	line := []vec3.T{{0, 0, 0}, {1, 1, 1}, {2, 2, 2}, {3, 3, 3}}
+	if _, err := FitToPoints(line); err == nil {
+		t.Error("expected an error for collinear points")
+	}
+}
+
+func TestSplitPolygon(t *testing.T) {
+	p := FromPointNormal(&vec3.T{1, 0, 0}, &vec3.UnitX)
+	quad := []vec3.T{{0, 0, 0}, {3, 0, 0}, {3, 2, 0}, {0, 2, 0}}
+	front, back := SplitPolygon(quad, &p, EPSILON)
+	if len(front) != 4 || len(back) != 4 {
+		t.Fatalf("split gives %d front and %d back vertices, want 4 each", len(front), len(back))
+	}
+	for i := range front {
+		if p.Classify(&front[i], EPSILON) == Back {
+			t.Errorf("front vertex %v is behind the plane", front[i])
+		}
+	}
+	for i := range back {
+		if p.Classify(&back[i], EPSILON) == Front {
+			t.Errorf("back vertex %v is in front of the plane", back[i])
+		}
+	}
+	if want := (vec3.T{1, 0, 0}); front[0] != want || back[1] != want {
+		t.Errorf("intersection vertices are %v and %v, want %v", front[0], back[1], want)
+	}
+
+	behind := FromPointNormal(&vec3.T{-1, 0, 0}, &vec3.UnitX)
+	front, back = SplitPolygon(quad[:3], &behind, EPSILON)
+	if len(front) != 3 || back != nil {
+		t.Errorf("polygon in front gives %v and %v", front, back)
+	}
+}
@@ -2,6 +2,7 @@
 package quaternion
 
 import (
+	"errors"
 	"fmt"
 	"math"
 
@@ -143,6 +144,29 @@ func (quat *T) Negated() T {
 	return T{-quat[0], -quat[1], -quat[2], -quat[3]}
 }
 
+// Canonicalize negates the quaternion if necessary so that every rotation
+// has a unique representation: W is made positive, and if W is zero
+// the first non zero component of X, Y, Z is made positive.
+func (quat *T) Canonicalize() *T {
+	for _, i := range [4]int{3, 0, 1, 2} {
+		if quat[i] != 0 {
+			if quat[i] < 0 {
+				quat.Negate()
+			}
+			break
+		}
+	}
+	return quat
+}
+
+// Canonicalized returns a canonicalized copy of the quaternion.
+// See Canonicalize
+func (quat *T) Canonicalized() T {
+	q := *quat
+	q.Canonicalize()
+	return q
+}
+
 // Invert inverts the quaterion.
 func (quat *T) Invert() *T {
 	quat[0] = -quat[0]
@@ -197,6 +221,15 @@ func (quat *T) RotatedVec3(v *vec3.T) vec3.T {
 	return vec3.T{q[0], q[1], q[2]}
 }
 
+// Delta returns the relative rotation to * from.Inverted() that takes from to to,
+// so that Mul(&delta, from) equals to.
+// The delta is expressed in world space, it is applied after from.
+// Both quaternions must have unit length.
+func Delta(from, to *T) T {
+	inv := from.Inverted()
+	return Mul(to, &inv)
+}
+
 // Dot returns the dot product of two quaternions.
 func Dot(a, b *T) float64 {
 	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2] + a[3]*b[3]
@@ -245,6 +278,78 @@ func Slerp(a, b *T, t float64) T {
 	return q.Normalized()
 }
 
+// SlerpSpins returns the spherical linear interpolation between a and b
+// with spins extra full revolutions added over the interval.
+// The extra revolutions are about the axis of the relative rotation from a to b,
+// so the result keeps moving along the same great circle as Slerp but travels
+// spins*2*Pi further. Negative spins revolve in the opposite direction.
+// SlerpSpins with spins == 0 is identical to Slerp.
+// a and b must not represent the same rotation, because then the axis is undefined.
+func SlerpSpins(a, b *T, t float64, spins int) T {
+	d := math.Acos(a[0]*b[0] + a[1]*b[1] + a[2]*b[2] + a[3]*b[3])
+	ooSinD := 1 / math.Sin(d)
+	// an angle of Pi on the unit 4D sphere is a full revolution in 3D
+	phi := d + float64(spins)*math.Pi
+
+	t1 := math.Sin(d-t*phi) * ooSinD
+	t2 := math.Sin(t*phi) * ooSinD
+
+	q := T{
+		a[0]*t1 + b[0]*t2,
+		a[1]*t1 + b[1]*t2,
+		a[2]*t1 + b[2]*t2,
+		a[3]*t1 + b[3]*t2,
+	}
+
+	return q.Normalized()
+}
+
+// SlerpPrepared holds the precomputed state for evaluating
+// many spherical linear interpolations between the same pair of quaternions.
+// Create it with PrepareSlerp.
+type SlerpPrepared struct {
+	a, b   T
+	angle  float64
+	ooSinD float64
+}
+
+// PrepareSlerp precomputes the angle between a and b and its sine for SlerpPrepared.At.
+// Unlike Slerp, b is negated if necessary so that the interpolation
+// takes the shortest path, and nearly identical quaternions
+// fall back to normalized linear interpolation.
+func PrepareSlerp(a, b *T) SlerpPrepared {
+	s := SlerpPrepared{a: *a, b: *b}
+	cosD := Dot(a, b)
+	if cosD < 0 {
+		s.b = b.Negated()
+		cosD = -cosD
+	}
+	if cosD > 1 {
+		cosD = 1
+	}
+	s.angle = math.Acos(cosD)
+	if sinD := math.Sin(s.angle); sinD > 1e-9 {
+		s.ooSinD = 1 / sinD
+	}
+	return s
+}
+
+// At returns the spherical linear interpolation quaternion at t (0,1).
+func (s *SlerpPrepared) At(t float64) T {
+	t1, t2 := 1-t, t
+	if s.ooSinD != 0 {
+		t1 = math.Sin(s.angle*(1-t)) * s.ooSinD
+		t2 = math.Sin(s.angle*t) * s.ooSinD
+	}
+	q := T{
+		s.a[0]*t1 + s.b[0]*t2,
+		s.a[1]*t1 + s.b[1]*t2,
+		s.a[2]*t1 + s.b[2]*t2,
+		s.a[3]*t1 + s.b[3]*t2,
+	}
+	return q.Normalized()
+}
+
 // Vec3Diff returns the rotation quaternion between two vectors.
 func Vec3Diff(a, b *vec3.T) T {
 	cr := vec3.Cross(a, b)
@@ -254,3 +359,368 @@ func Vec3Diff(a, b *vec3.T) T {
 	q := T{cr[0] * oosr, cr[1] * oosr, cr[2] * oosr, sr * 0.5}
 	return q.Normalized()
 }
+
+// MakeShortestPath negates keys in place where necessary so that
+// the rotation from every key to the next one is the shortest possible rotation.
+// Use it on animation tracks to prevent interpolation from taking the long way around.
+// See T.SetShortestRotation
+func MakeShortestPath(keys []T) {
+	for i := 1; i < len(keys); i++ {
+		keys[i].SetShortestRotation(&keys[i-1])
+	}
+}
+
+// WeightedAverage returns the weighted average rotation of quats using Markley's method:
+// the result is the eigenvector with the largest eigenvalue of the
+// weighted sum of the outer products of the quaternions.
+// Because the outer product of q equals that of -q, the average is robust
+// to the antipodal representations of a rotation.
+// The sign of the result is chosen to lie in the hemisphere of quats[0].
+// An error is returned if quats is empty, if the lengths of quats and weights differ
+// or if the weights don't sum up to a positive value.
+func WeightedAverage(quats []T, weights []float64) (T, error) {
+	if len(quats) == 0 {
+		return Zero, errors.New("quaternion.WeightedAverage: no quaternions")
+	}
+	if len(quats) != len(weights) {
+		return Zero, errors.New("quaternion.WeightedAverage: number of quaternions and weights differ")
+	}
+	var m [4][4]float64
+	var weightSum float64
+	for i := range quats {
+		q := quats[i].Normalized()
+		w := weights[i]
+		weightSum += w
+		for row := 0; row < 4; row++ {
+			for col := 0; col < 4; col++ {
+				m[row][col] += w * q[row] * q[col]
+			}
+		}
+	}
+	if weightSum <= 0 {
+		return Zero, errors.New("quaternion.WeightedAverage: weights must sum up to a positive value")
+	}
+	values, vectors := jacobiEigen4(m)
+	best := 0
+	for i := 1; i < 4; i++ {
+		if values[i] > values[best] {
+			best = i
+		}
+	}
+	result := T{vectors[0][best], vectors[1][best], vectors[2][best], vectors[3][best]}
+	result.Normalize()
+	result.SetShortestRotation(&quats[0])
+	return result, nil
+}
+
+// jacobiEigen4 returns the eigenvalues and the eigenvectors (as columns)
+// of the symmetric matrix a using the cyclic Jacobi method.
+func jacobiEigen4(a [4][4]float64) (values [4]float64, vectors [4][4]float64) {
+	for i := range vectors {
+		vectors[i][i] = 1
+	}
+	for sweep := 0; sweep < 50; sweep++ {
+		var off float64
+		for p := 0; p < 4; p++ {
+			for q := p + 1; q < 4; q++ {
+				off += a[p][q] * a[p][q]
+			}
+		}
+		if off < 1e-30 {
+			break
+		}
+		for p := 0; p < 4; p++ {
+			for q := p + 1; q < 4; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				for k := 0; k < 4; k++ {
+					akp, akq := a[k][p], a[k][q]
+					a[k][p] = c*akp - s*akq
+					a[k][q] = s*akp + c*akq
+				}
+				for k := 0; k < 4; k++ {
+					apk, aqk := a[p][k], a[q][k]
+					a[p][k] = c*apk - s*aqk
+					a[q][k] = s*apk + c*aqk
+				}
+				for k := 0; k < 4; k++ {
+					vkp, vkq := vectors[k][p], vectors[k][q]
+					vectors[k][p] = c*vkp - s*vkq
+					vectors[k][q] = s*vkp + c*vkq
+				}
+			}
+		}
+	}
+	for i := range values {
+		values[i] = a[i][i]
+	}
+	return values, vectors
+}
+
+// FromBasis returns the rotation that maps the unit axes X, Y, Z to the orthonormal
+// right-handed basis vectors x, y, z.
+// It is the quaternion of the rotation matrix with the columns x, y, z
+// and uses Shepperd's method to stay accurate for all rotation angles.
+func FromBasis(x, y, z *vec3.T) T {
+	var q T
+	trace := x[0] + y[1] + z[2]
+	switch {
+	case trace > 0:
+		s := 0.5 / math.Sqrt(trace+1)
+		q = T{(y[2] - z[1]) * s, (z[0] - x[2]) * s, (x[1] - y[0]) * s, 0.25 / s}
+	case x[0] > y[1] && x[0] > z[2]:
+		s := 2 * math.Sqrt(1+x[0]-y[1]-z[2])
+		q = T{0.25 * s, (y[0] + x[1]) / s, (z[0] + x[2]) / s, (y[2] - z[1]) / s}
+	case y[1] > z[2]:
+		s := 2 * math.Sqrt(1+y[1]-x[0]-z[2])
+		q = T{(y[0] + x[1]) / s, 0.25 * s, (z[1] + y[2]) / s, (z[0] - x[2]) / s}
+	default:
+		s := 2 * math.Sqrt(1+z[2]-x[0]-y[1])
+		q = T{(z[0] + x[2]) / s, (z[1] + y[2]) / s, 0.25 * s, (x[1] - y[0]) / s}
+	}
+	return q.Normalized()
+}
+
+// BillboardRotation returns the rotation that turns an object at objectPos
+// so that its local positive Z axis faces the camera at cameraPos
+// and its local Y axis is as close as possible to up.
+// If the direction to the camera is parallel to up, a perpendicular up direction is chosen.
+func BillboardRotation(objectPos, cameraPos, up *vec3.T) T {
+	z := vec3.Sub(cameraPos, objectPos)
+	z.Normalize()
+	x := vec3.Cross(up, &z)
+	if x.LengthSqr() < 1e-12 {
+		alt := vec3.UnitY
+		if math.Abs(z[1]) > 0.9 {
+			alt = vec3.UnitZ
+		}
+		x = vec3.Cross(&alt, &z)
+	}
+	x.Normalize()
+	y := vec3.Cross(&z, &x)
+	return FromBasis(&x, &y, &z)
+}
+
+// To6D returns the continuous 6D representation of the rotation,
+// which are the first two columns of the rotation matrix,
+// the rotated X axis followed by the rotated Y axis.
+// See From6D
+func (quat *T) To6D() [6]float64 {
+	xx := quat[0] * quat[0] * 2
+	yy := quat[1] * quat[1] * 2
+	zz := quat[2] * quat[2] * 2
+	xy := quat[0] * quat[1] * 2
+	xz := quat[0] * quat[2] * 2
+	yz := quat[1] * quat[2] * 2
+	wx := quat[3] * quat[0] * 2
+	wy := quat[3] * quat[1] * 2
+	wz := quat[3] * quat[2] * 2
+	return [6]float64{
+		1 - (yy + zz), xy + wz, xz - wy,
+		xy - wz, 1 - (xx + zz), yz + wx,
+	}
+}
+
+// From6D returns the rotation of the continuous 6D representation r.
+// The two 3D columns of r don't need to be orthonormal,
+// they are orthonormalized with the Gram-Schmidt process
+// and completed with their cross product to a rotation matrix.
+// Linearly dependent columns result in an undefined rotation.
+// See To6D
+func From6D(r [6]float64) T {
+	x := vec3.T{r[0], r[1], r[2]}
+	y := vec3.T{r[3], r[4], r[5]}
+	x.Normalize()
+	d := x.Scaled(vec3.Dot(&x, &y))
+	y.Sub(&d).Normalize()
+	z := vec3.Cross(&x, &y)
+	return FromBasis(&x, &y, &z)
+}
+
+// BankingRotation returns the roll rotation around forward that banks
+// an aircraft-like object into a turn, tilting up towards the lateral
+// component of acceleration like a coordinated turn does.
+// The roll angle is atan2(lateral acceleration, gravity), where the lateral
+// acceleration is the component of acceleration along the right direction forward × up.
+// A positive lateral acceleration (a turn to the right) banks to the right.
+// Apply the result after the heading orientation of the object.
+func BankingRotation(forward, up, acceleration *vec3.T, gravity float64) T {
+	f := forward.Normalized()
+	right := vec3.Cross(&f, up)
+	if right.LengthSqr() < 1e-24 {
+		return Ident
+	}
+	right.Normalize()
+	roll := math.Atan2(vec3.Dot(acceleration, &right), gravity)
+	return FromAxisAngle(&f, roll)
+}
+
+// EncodeTangentFrame encodes the tangent frame of normal and tangent
+// with the bitangent normal × tangent * handedness into a single quaternion,
+// as used for compact vertex data.
+// The tangent is made orthogonal to the normal first.
+// The rotation maps X to the tangent, Y to normal × tangent and Z to the normal,
+// and the sign of the W component stores the handedness:
+// W is negative for a negative handedness and positive otherwise.
+// See DecodeTangentFrame
+func EncodeTangentFrame(normal, tangent *vec3.T, handedness float64) T {
+	n := normal.Normalized()
+	d := n.Scaled(vec3.Dot(tangent, &n))
+	t := vec3.Sub(tangent, &d)
+	t.Normalize()
+	b := vec3.Cross(&n, &t)
+	q := FromBasis(&t, &b, &n)
+	if q[3] < 0 {
+		q.Negate()
+	}
+	// W must not be zero so that it can carry the sign
+	const bias = 1e-9
+	if q[3] < bias {
+		q[3] = bias
+		q.Normalize()
+	}
+	if handedness < 0 {
+		q.Negate()
+	}
+	return q
+}
+
+// DecodeTangentFrame decodes a tangent frame encoded by EncodeTangentFrame.
+// The bitangent is normal × tangent multiplied by the handedness
+// stored in the sign of the W component of q.
+func DecodeTangentFrame(q *T) (normal, tangent, bitangent vec3.T) {
+	r := q.To6D()
+	tangent = vec3.T{r[0], r[1], r[2]}
+	y := vec3.T{r[3], r[4], r[5]}
+	normal = vec3.Cross(&tangent, &y)
+	bitangent = y
+	if q[3] < 0 {
+		bitangent.Invert()
+	}
+	return normal, tangent, bitangent
+}
+
+// SmoothDamp rotates current towards target like a critically damped spring
+// and returns the new rotation after the time step dt.
+// velocity is the angular velocity in radians per second in world space
+// that is carried from one call to the next and updated in place.
+// smoothTime is the approximate time to reach the target,
+// for smoothTime <= 0 target is returned and velocity is set to zero.
+// The rotation never overshoots the target.
+func SmoothDamp(current, target *T, velocity *vec3.T, smoothTime, dt float64) T {
+	if smoothTime <= 0 {
+		*velocity = vec3.Zero
+		return *target
+	}
+	// offset is the rotation vector from target to current
+	delta := Delta(target, current)
+	offset := delta.rotationVector()
+
+	omega := 2 / smoothTime
+	x := omega * dt
+	exp := 1 / (1 + x + 0.48*x*x + 0.235*x*x*x)
+	temp := offset.Scaled(omega)
+	temp.Add(velocity).Scale(dt)
+	v := temp.Scaled(omega)
+	v = vec3.Sub(velocity, &v)
+	*velocity = v.Scaled(exp)
+	newOffset := vec3.Add(&offset, &temp)
+	newOffset.Scale(exp)
+
+	if vec3.Dot(&offset, &newOffset) < 0 {
+		*velocity = vec3.Zero
+		return *target
+	}
+	angle := newOffset.Length()
+	if angle == 0 {
+		return *target
+	}
+	newOffset.Scale(1 / angle)
+	q := FromAxisAngle(&newOffset, angle)
+	return Mul(&q, target)
+}
+
+// rotationVector returns the rotation axis scaled by the rotation angle
+// in the range [0,π] of the unit quaternion.
+func (quat *T) rotationVector() vec3.T {
+	v := vec3.T{quat[0], quat[1], quat[2]}
+	w := quat[3]
+	if w < 0 {
+		v.Invert()
+		w = -w
+	}
+	sinHalf := v.Length()
+	if sinHalf == 0 {
+		return vec3.Zero
+	}
+	v.Scale(2 * math.Atan2(sinHalf, w) / sinHalf)
+	return v
+}
+
+// ComplementaryFilter returns the orientation current, which rotates from the
+// sensor frame to the world frame, advanced by the time step dt.
+// The angular rate gyro in radians per second in the sensor frame is integrated
+// and the resulting tilt drift is corrected by the fraction alpha towards the
+// orientation whose world up matches the accelerometer reading accel.
+// The accelerometer is assumed to measure only the reaction to gravity,
+// so it points up along the world positive Y axis when the sensor is resting
+// and linear accelerations appear as tilt errors.
+// The heading around the world up axis is not observable from gravity
+// and remains gyro integrated.
+// An alpha of 0 integrates the gyro only, an alpha of 1 fully trusts the accelerometer.
+func ComplementaryFilter(current *T, gyro *vec3.T, accel *vec3.T, dt, alpha float64) T {
+	result := *current
+	if angle := gyro.Length() * dt; angle > 0 {
+		axis := gyro.Normalized()
+		step := FromAxisAngle(&axis, angle)
+		// the rate is measured in the sensor frame, so it is applied first
+		result = Mul(&result, &step)
+	}
+	if accel.IsZero() || alpha <= 0 {
+		return result
+	}
+	measured := accel.Normalized()
+	up := result.RotatedVec3(&measured)
+	axis := vec3.Cross(&up, &vec3.UnitY)
+	angle := math.Atan2(axis.Length(), vec3.Dot(&up, &vec3.UnitY))
+	if angle == 0 {
+		return result
+	}
+	if axis.LengthSqr() < 1e-24 {
+		// upside down, any horizontal axis corrects the tilt
+		axis = vec3.UnitX
+	} else {
+		axis.Normalize()
+	}
+	correction := FromAxisAngle(&axis, angle*math.Min(alpha, 1))
+	return Mul(&correction, &result)
+}
+
+// LookAtWithRoll returns the orientation of a camera at eye that looks at target
+// and is rolled by rollRadians around its view direction.
+// Like mat4.LookAt the camera looks down its local negative Z axis with the local
+// Y axis as up, which is as close as possible to the world positive Y axis before the roll.
+// A positive roll turns the camera counter-clockwise as seen through it,
+// tilting its up vector towards its left.
+// If the view direction is vertical, a perpendicular up direction is chosen.
+func LookAtWithRoll(eye, target *vec3.T, rollRadians float64) T {
+	z := vec3.Sub(eye, target)
+	z.Normalize()
+	x := vec3.Cross(&vec3.UnitY, &z)
+	if x.LengthSqr() < 1e-12 {
+		x = vec3.Cross(&vec3.UnitZ, &z)
+	}
+	x.Normalize()
+	y := vec3.Cross(&z, &x)
+	orientation := FromBasis(&x, &y, &z)
+	roll := FromZAxisAngle(rollRadians)
+	return Mul(&orientation, &roll)
+}
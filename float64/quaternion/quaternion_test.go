@@ -0,0 +1,372 @@
+package quaternion
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+const EPSILON = 0.000001
+
+func quatEqual(a, b *T, epsilon float64) bool {
+	return math.Abs(a[0]-b[0]) <= epsilon && math.Abs(a[1]-b[1]) <= epsilon &&
+		math.Abs(a[2]-b[2]) <= epsilon && math.Abs(a[3]-b[3]) <= epsilon
+}
+
+func TestMakeShortestPath(t *testing.T) {
+	keys := make([]T, 6)
+	for i := range keys {
+		keys[i] = FromYAxisAngle(float64(i) * 0.4)
+		if i%2 == 1 {
+			keys[i].Negate()
+		}
+	}
+	MakeShortestPath(keys)
+	for i := 1; i < len(keys); i++ {
+		if d := Dot(&keys[i-1], &keys[i]); d < 0 {
+			t.Errorf("dot of keys %d and %d is negative: %v", i-1, i, d)
+		}
+	}
+	if q := FromYAxisAngle(0); keys[0] != q {
+		t.Errorf("first key changed to %v", keys[0])
+	}
+}
+
+func TestWeightedAverage(t *testing.T) {
+	a := FromYAxisAngle(0.2)
+	b := FromYAxisAngle(0.5)
+	avg, err := WeightedAverage([]T{a, b}, []float64{1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mid := Slerp(&a, &b, 0.5); !quatEqual(&avg, &mid, EPSILON) {
+		t.Errorf("average of %v and %v is %v, want %v", a, b, avg, mid)
+	}
+
+	// antipodal representation of b must not change the result
+	avg, err = WeightedAverage([]T{a, b.Negated()}, []float64{1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mid := Slerp(&a, &b, 0.5); !quatEqual(&avg, &mid, EPSILON) {
+		t.Errorf("average with negated b is %v, want %v", avg, mid)
+	}
+
+	x := FromXAxisAngle(0.3)
+	avg, err = WeightedAverage([]T{x, a}, []float64{1, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !quatEqual(&avg, &x, EPSILON) {
+		t.Errorf("average with zero weight for the second rotation is %v, want %v", avg, x)
+	}
+
+	if _, err := WeightedAverage([]T{a, b}, []float64{1}); err == nil {
+		t.Error("expected an error for mismatched lengths")
+	}
+}
+
+func TestFromBasis(t *testing.T) {
+	for _, q := range []T{Ident, FromXAxisAngle(3), FromYAxisAngle(-2.5), FromZAxisAngle(math.Pi), FromEulerAngles(0.3, 2, -1)} {
+		x := q.RotatedVec3(&vec3.UnitX)
+		y := q.RotatedVec3(&vec3.UnitY)
+		z := q.RotatedVec3(&vec3.UnitZ)
+		got := FromBasis(&x, &y, &z)
+		if !quatEqual(&got, &q, EPSILON) && !quatEqual(&got, &T{-q[0], -q[1], -q[2], -q[3]}, EPSILON) {
+			t.Errorf("FromBasis returned %v, want %v", got, q)
+		}
+	}
+}
+
+func TestBillboardRotation(t *testing.T) {
+	object := vec3.T{1, 0, 2}
+	for _, camera := range []vec3.T{{5, 3, -4}, {1, 0, 10}, {1, 8, 2}} {
+		q := BillboardRotation(&object, &camera, &vec3.UnitY)
+		forward := q.RotatedVec3(&vec3.UnitZ)
+		want := vec3.Sub(&camera, &object)
+		want.Normalize()
+		if vec3.Distance(&forward, &want) > EPSILON {
+			t.Errorf("rotated forward axis is %v, want %v", forward, want)
+		}
+		up := q.RotatedVec3(&vec3.UnitY)
+		if camera[1] == 0 && vec3.Distance(&up, &vec3.UnitY) > EPSILON {
+			t.Errorf("rotated up axis is %v, want %v", up, vec3.UnitY)
+		}
+	}
+}
+
+func TestSlerpPrepared(t *testing.T) {
+	a := FromEulerAngles(0.2, 0.5, -0.3)
+	b := FromEulerAngles(1.4, -0.2, 0.6)
+	prepared := PrepareSlerp(&a, &b)
+	for _, s := range []float64{0, 0.1, 0.25, 0.5, 0.9, 1} {
+		got := prepared.At(s)
+		want := Slerp(&a, &b, s)
+		if !quatEqual(&got, &want, EPSILON) {
+			t.Errorf("At(%v) is %v, want %v", s, got, want)
+		}
+	}
+
+	same := PrepareSlerp(&a, &a)
+	if got := same.At(0.5); !quatEqual(&got, &a, EPSILON) {
+		t.Errorf("At between identical quaternions is %v, want %v", got, a)
+	}
+}
+
+func BenchmarkSlerp(b *testing.B) {
+	q0 := FromEulerAngles(0.2, 0.5, -0.3)
+	q1 := FromEulerAngles(1.4, -0.2, 0.6)
+	var q T
+	for i := 0; i < b.N; i++ {
+		q = Slerp(&q0, &q1, float64(i%100)*0.01)
+	}
+	_ = q
+}
+
+func BenchmarkSlerpPrepared(b *testing.B) {
+	q0 := FromEulerAngles(0.2, 0.5, -0.3)
+	q1 := FromEulerAngles(1.4, -0.2, 0.6)
+	prepared := PrepareSlerp(&q0, &q1)
+	var q T
+	for i := 0; i < b.N; i++ {
+		q = prepared.At(float64(i%100) * 0.01)
+	}
+	_ = q
+}
+
+func Test6D(t *testing.T) {
+	for _, q := range []T{Ident, FromEulerAngles(0.3, -1.2, 2.0), FromXAxisAngle(math.Pi)} {
+		r := q.To6D()
+		back := From6D(r)
+		if math.Abs(math.Abs(Dot(&back, &q))-1) > EPSILON {
+			t.Errorf("round trip of %v gives %v", q, back)
+		}
+	}
+
+	q := From6D([6]float64{2, 0.1, 0, 0.5, 3, 0})
+	if !q.IsUnitQuat(EPSILON) {
+		t.Errorf("non orthonormal input gives non unit quaternion %v", q)
+	}
+	// the X axis keeps its direction, Y is made orthogonal to it in the XY plane
+	r := q.To6D()
+	x := vec3.T{r[0], r[1], r[2]}
+	want := vec3.T{2, 0.1, 0}
+	want.Normalize()
+	if vec3.Distance(&x, &want) > EPSILON || r[5] > EPSILON || r[5] < -EPSILON {
+		t.Errorf("orthonormalized 6D representation is %v", r)
+	}
+}
+
+func TestBankingRotation(t *testing.T) {
+	forward := vec3.T{0, 0, -1}
+	up := vec3.UnitY
+	right := vec3.UnitX
+
+	straight := vec3.T{0, 0, -3}
+	if q := BankingRotation(&forward, &up, &straight, 9.81); !quatEqual(&q, &Ident, EPSILON) {
+		t.Errorf("straight flight gives roll %v, want identity", q)
+	}
+
+	turn := vec3.T{9.81, 0, 0}
+	q := BankingRotation(&forward, &up, &turn, 9.81)
+	tilted := q.RotatedVec3(&up)
+	if vec3.Dot(&tilted, &right) <= 0 {
+		t.Errorf("right turn tilts up to %v, want a tilt to the right", tilted)
+	}
+	// lateral acceleration equal to gravity banks by 45 degrees
+	if angle := vec3.AngleBetween(&tilted, &up); math.Abs(angle-math.Pi/4) > EPSILON {
+		t.Errorf("bank angle is %v, want %v", angle, math.Pi/4)
+	}
+
+	turn.Invert()
+	q = BankingRotation(&forward, &up, &turn, 9.81)
+	if tilted := q.RotatedVec3(&up); vec3.Dot(&tilted, &right) >= 0 {
+		t.Errorf("left turn tilts up to %v, want a tilt to the left", tilted)
+	}
+}
+
+func TestTangentFrame(t *testing.T) {
+	normal := vec3.T{0.2, 1, -0.4}
+	normal.Normalize()
+	tangent := vec3.T{1, 0, 0.3}
+	// make the tangent orthogonal to compare it after decoding
+	d := normal.Scaled(vec3.Dot(&tangent, &normal))
+	tangent.Sub(&d).Normalize()
+	cross := vec3.Cross(&normal, &tangent)
+
+	for _, handedness := range []float64{1, -1} {
+		q := EncodeTangentFrame(&normal, &tangent, handedness)
+		if (q[3] < 0) != (handedness < 0) {
+			t.Errorf("handedness %v is encoded as %v", handedness, q)
+		}
+		n, tan, b := DecodeTangentFrame(&q)
+		wantB := cross.Scaled(handedness)
+		if vec3.Distance(&n, &normal) > EPSILON || vec3.Distance(&tan, &tangent) > EPSILON || vec3.Distance(&b, &wantB) > EPSILON {
+			t.Errorf("handedness %v decodes to %v %v %v, want %v %v %v", handedness, n, tan, b, normal, tangent, wantB)
+		}
+	}
+
+	// a frame with a rotation of exactly π has W = 0, which needs the bias to store the sign
+	down := vec3.T{0, 0, -1}
+	q := EncodeTangentFrame(&down, &vec3.UnitX, -1)
+	if q[3] >= 0 {
+		t.Errorf("negative handedness of a π rotation is lost: %v", q)
+	}
+	if n, _, _ := DecodeTangentFrame(&q); vec3.Distance(&n, &down) > EPSILON {
+		t.Errorf("normal of the π rotation decodes to %v, want %v", n, down)
+	}
+}
+
+func TestSlerpSpins(t *testing.T) {
+	a := FromXAxisAngle(0.3)
+	b := FromYAxisAngle(1.2)
+	for _, f := range []float64{0, 0.25, 0.5, 1} {
+		s := Slerp(&a, &b, f)
+		q := SlerpSpins(&a, &b, f, 0)
+		if !quatEqual(&s, &q, EPSILON) {
+			t.Errorf("SlerpSpins(%v, 0) = %v, want Slerp %v", f, q, s)
+		}
+	}
+
+	c := Ident
+	d := FromZAxisAngle(math.Pi / 2)
+	for _, f := range []float64{0, 0.25, 0.5, 0.75, 1} {
+		q := SlerpSpins(&c, &d, f, 1)
+		got := q.RotatedVec3(&vec3.UnitX)
+		angle := f * (math.Pi/2 + 2*math.Pi)
+		want := vec3.T{math.Cos(angle), math.Sin(angle), 0}
+		if vec3.Distance(&got, &want) > EPSILON {
+			t.Errorf("SlerpSpins(%v, 1) rotates UnitX to %v, want %v", f, got, want)
+		}
+	}
+}
+
+func TestDelta(t *testing.T) {
+	a := FromEulerAngles(0.4, -0.2, 1.3)
+	b := FromEulerAngles(-1.1, 0.7, 0.1)
+	delta := Delta(&a, &b)
+	if got := Mul(&delta, &a); !quatEqual(&got, &b, EPSILON) {
+		t.Errorf("Delta applied to %v gives %v, want %v", a, got, b)
+	}
+	if got := Delta(&a, &a); !quatEqual(&got, &Ident, EPSILON) {
+		t.Errorf("Delta of equal rotations is %v, want ident", got)
+	}
+}
+
+func TestSmoothDamp(t *testing.T) {
+	target := FromEulerAngles(1.2, -0.4, 0.3)
+	current := FromEulerAngles(-0.5, 0.6, 0)
+	var velocity vec3.T
+	start := Delta(&target, &current)
+	startOffset := start.rotationVector()
+	lastAngle := startOffset.Length()
+	for i := 0; i < 300; i++ {
+		current = SmoothDamp(&current, &target, &velocity, 0.3, 1.0/60)
+		delta := Delta(&target, &current)
+		offset := delta.rotationVector()
+		if vec3.Dot(&offset, &startOffset) < 0 {
+			t.Fatalf("step %d overshot the target", i)
+		}
+		if angle := offset.Length(); angle > lastAngle+EPSILON {
+			t.Fatalf("step %d moved away from the target: %v > %v", i, angle, lastAngle)
+		} else {
+			lastAngle = angle
+		}
+	}
+	if lastAngle > 1e-3 {
+		t.Errorf("rotation did not converge, %v radians left", lastAngle)
+	}
+
+	velocity = vec3.T{1, 2, 3}
+	if q := SmoothDamp(&Ident, &target, &velocity, 0, 1.0/60); q != target || velocity != vec3.Zero {
+		t.Errorf("SmoothDamp with smoothTime 0 returned %v with velocity %v, want %v", q, velocity, target)
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	quats := []T{
+		FromEulerAngles(0.3, -1.2, 2.5),
+		FromYAxisAngle(math.Pi),
+		{0, -0.6, 0.8, 0},
+		{0, 0, -1, 0},
+	}
+	for _, q := range quats {
+		a := q.Canonicalized()
+		n := q.Negated()
+		b := n.Canonicalized()
+		if a != b {
+			t.Errorf("%v and its negation canonicalize to %v and %v", q, a, b)
+		}
+		if a[3] < 0 || (a[3] == 0 && a[0] < 0) {
+			t.Errorf("%v canonicalized to %v", q, a)
+		}
+	}
+}
+
+func TestComplementaryFilter(t *testing.T) {
+	tilt := func(q *T) float64 {
+		up := q.RotatedVec3(&vec3.UnitY)
+		return math.Acos(math.Max(-1, math.Min(vec3.Dot(&up, &vec3.UnitY), 1)))
+	}
+	const dt = 0.01
+	// the sensor rests level, but the gyro has a bias around the Z axis
+	gyroBias := vec3.T{0, 0, 0.1}
+	accel := vec3.T{0, 9.81, 0}
+
+	integrated := Ident
+	filtered := Ident
+	for i := 0; i < 1000; i++ {
+		integrated = ComplementaryFilter(&integrated, &gyroBias, &accel, dt, 0)
+		filtered = ComplementaryFilter(&filtered, &gyroBias, &accel, dt, 0.05)
+	}
+	if a := tilt(&integrated); math.Abs(a-1) > 1e-6 {
+		t.Errorf("gyro only tilt is %v, want 1", a)
+	}
+	// the drift balances with the correction at about bias*dt/alpha
+	if a := tilt(&filtered); a > 0.03 {
+		t.Errorf("filtered tilt is %v, want it corrected to near level", a)
+	}
+
+	// a tilted orientation without rotation rate converges back to level
+	q := FromXAxisAngle(0.5)
+	for i := 0; i < 300; i++ {
+		q = ComplementaryFilter(&q, &vec3.Zero, &accel, dt, 0.05)
+	}
+	if a := tilt(&q); a > 1e-6 {
+		t.Errorf("tilt after correcting is %v, want level", a)
+	}
+}
+
+func TestLookAtWithRoll(t *testing.T) {
+	eye := vec3.T{1, 2, 3}
+	target := vec3.T{4, 0, -1}
+	toTarget := vec3.Sub(&target, &eye)
+	toTarget.Normalize()
+	forward := vec3.T{0, 0, -1}
+
+	level := LookAtWithRoll(&eye, &target, 0)
+	if f := level.RotatedVec3(&forward); vec3.Distance(&f, &toTarget) > 1e-12 {
+		t.Errorf("forward is %v, want %v", f, toTarget)
+	}
+	up := level.RotatedVec3(&vec3.UnitY)
+	right := level.RotatedVec3(&vec3.UnitX)
+	if up[1] <= 0 || math.Abs(right[1]) > 1e-12 {
+		t.Errorf("unrolled camera is not level, up %v, right %v", up, right)
+	}
+
+	rolled := LookAtWithRoll(&eye, &target, math.Pi/2)
+	if f := rolled.RotatedVec3(&forward); vec3.Distance(&f, &toTarget) > 1e-12 {
+		t.Errorf("rolled forward is %v, want %v", f, toTarget)
+	}
+	left := right.Inverted()
+	if u := rolled.RotatedVec3(&vec3.UnitY); vec3.Distance(&u, &left) > 1e-12 {
+		t.Errorf("up rolled by a quarter turn is %v, want %v", u, left)
+	}
+
+	// looking straight down
+	down := LookAtWithRoll(&eye, &vec3.T{1, -5, 3}, 0.3)
+	if f := down.RotatedVec3(&forward); vec3.Distance(&f, &vec3.T{0, -1, 0}) > 1e-12 {
+		t.Errorf("vertical forward is %v, want %v", f, vec3.T{0, -1, 0})
+	}
+}
@@ -0,0 +1,124 @@
+package vec2
+
+import (
+	"errors"
+)
+
+// delaunayGhost is the vertex index of the point at infinity.
+// A ghost triangle a, b, delaunayGhost stands for the outside of the
+// convex hull edge from a to b, which lies to the left of the edge.
+const delaunayGhost = -1
+
+// delaunayConflict returns if p lies strictly inside the circumcircle of the
+// counter-clockwise triangle tri. The circumcircle of a ghost triangle is the
+// open half plane left of its hull edge plus the open edge segment itself.
+func delaunayConflict(points []T, tri [3]int, p *T) bool {
+	a, b := &points[tri[0]], &points[tri[1]]
+	if tri[2] == delaunayGhost {
+		if t := turn(a, b, p); t != 0 {
+			return t > 0
+		}
+		// on the line of the hull edge, in conflict only between its end points
+		pa, pb := Sub(a, p), Sub(b, p)
+		return Dot(&pa, &pb) < 0
+	}
+	c := &points[tri[2]]
+	adx, ady := a[0]-p[0], a[1]-p[1]
+	bdx, bdy := b[0]-p[0], b[1]-p[1]
+	cdx, cdy := c[0]-p[0], c[1]-p[1]
+	det := (adx*adx+ady*ady)*(bdx*cdy-cdx*bdy) +
+		(bdx*bdx+bdy*bdy)*(cdx*ady-adx*cdy) +
+		(cdx*cdx+cdy*cdy)*(adx*bdy-bdx*ady)
+	return det > 0
+}
+
+// DelaunayTriangulate returns the Delaunay triangulation of points
+// as triples of indices into points, computed with the Bowyer-Watson algorithm.
+// No point lies inside the circumcircle of any triangle,
+// the triangles cover the convex hull of the points and
+// all triangles are wound counter-clockwise.
+// Instead of a finite super triangle, the outside of every hull edge is represented
+// by a ghost triangle with a vertex at infinity, so no hull triangles get lost.
+// Duplicate points are ignored. An error is returned for less than 3 distinct points
+// or if all points are collinear.
+func DelaunayTriangulate(points []T) ([][3]int, error) {
+	if len(points) < 3 {
+		return nil, errors.New("vec2.DelaunayTriangulate: at least 3 points required")
+	}
+	// start with the first non degenerate triangle
+	i0, i1, i2 := 0, -1, -1
+	for i := 1; i < len(points) && i2 < 0; i++ {
+		switch {
+		case i1 < 0:
+			if points[i] != points[i0] {
+				i1 = i
+			}
+		case turn(&points[i0], &points[i1], &points[i]) != 0:
+			i2 = i
+		}
+	}
+	if i1 < 0 {
+		return nil, errors.New("vec2.DelaunayTriangulate: at least 3 points required")
+	}
+	if i2 < 0 {
+		return nil, errors.New("vec2.DelaunayTriangulate: all points are collinear")
+	}
+	if turn(&points[i0], &points[i1], &points[i2]) < 0 {
+		i1, i2 = i2, i1
+	}
+	triangles := [][3]int{
+		{i0, i1, i2},
+		{i1, i0, delaunayGhost},
+		{i2, i1, delaunayGhost},
+		{i0, i2, delaunayGhost},
+	}
+
+	seen := map[T]bool{points[i0]: true, points[i1]: true, points[i2]: true}
+	for i := range points {
+		p := &points[i]
+		if seen[*p] {
+			continue
+		}
+		seen[*p] = true
+
+		// remove all triangles whose circumcircle contains p
+		// and remember the directed edges of the resulting polygonal hole
+		var edges [][2]int
+		hole := make(map[[2]int]bool)
+		kept := triangles[:0]
+		for _, tri := range triangles {
+			if delaunayConflict(points, tri, p) {
+				for j := 0; j < 3; j++ {
+					edge := [2]int{tri[j], tri[(j+1)%3]}
+					edges = append(edges, edge)
+					hole[edge] = true
+				}
+			} else {
+				kept = append(kept, tri)
+			}
+		}
+		triangles = kept
+		// edges shared by two removed triangles are inside the hole
+		for _, edge := range edges {
+			if hole[[2]int{edge[1], edge[0]}] {
+				continue
+			}
+			switch delaunayGhost {
+			case edge[0]:
+				triangles = append(triangles, [3]int{edge[1], i, delaunayGhost})
+			case edge[1]:
+				triangles = append(triangles, [3]int{i, edge[0], delaunayGhost})
+			default:
+				triangles = append(triangles, [3]int{edge[0], edge[1], i})
+			}
+		}
+	}
+
+	result := make([][3]int, 0, len(triangles))
+	for _, tri := range triangles {
+		if tri[2] != delaunayGhost {
+			result = append(result, tri)
+		}
+	}
+	return result, nil
+}
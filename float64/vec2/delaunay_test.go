@@ -0,0 +1,89 @@
+package vec2
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// checkDelaunay checks that the triangles are counter-clockwise,
+// have empty circumcircles and cover the convex hull of the points.
+func checkDelaunay(t *testing.T, name string, points []T, triangles [][3]int) {
+	t.Helper()
+	hull := ConvexHull(points)
+	hullArea := 0.0
+	for i := range hull {
+		hullArea += turn(&hull[0], &hull[i], &hull[(i+1)%len(hull)]) / 2
+	}
+	area := 0.0
+	for _, tri := range triangles {
+		a := turn(&points[tri[0]], &points[tri[1]], &points[tri[2]]) / 2
+		if a <= 0 {
+			t.Errorf("%s: triangle %v is not counter-clockwise", name, tri)
+		}
+		area += a
+		for i := range points {
+			if i != tri[0] && i != tri[1] && i != tri[2] && delaunayConflict(points, tri, &points[i]) {
+				t.Errorf("%s: point %d is inside the circumcircle of %v", name, i, tri)
+			}
+		}
+	}
+	if math.Abs(area-hullArea) > 1e-9*hullArea {
+		t.Errorf("%s: triangles cover the area %v, want the hull area %v", name, area, hullArea)
+	}
+}
+
+func TestDelaunayTriangulate(t *testing.T) {
+	square := []T{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+	triangles, err := DelaunayTriangulate(square)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(triangles) != 2 {
+		t.Errorf("square has %d triangles, want 2: %v", len(triangles), triangles)
+	}
+	checkDelaunay(t, "square", square, triangles)
+
+	// collinear points on the hull are triangulated as well
+	flat := []T{{0, 0}, {1, 0}, {2, 0}, {3, 0}, {1.5, 0.001}}
+	triangles, err = DelaunayTriangulate(flat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(triangles) != 3 {
+		t.Errorf("nearly collinear points have %d triangles, want 3: %v", len(triangles), triangles)
+	}
+	checkDelaunay(t, "nearly collinear", flat, triangles)
+
+	for seed := int64(0); seed < 300; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		// alternate between a square and a thin strip of points
+		width, height := 10.0, 10.0
+		if seed%2 == 1 {
+			width, height = 100, 1
+		}
+		points := make([]T, 50)
+		for i := range points {
+			points[i] = T{rng.Float64() * width, rng.Float64() * height}
+		}
+		triangles, err := DelaunayTriangulate(points)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// a triangulation of n points with h points on the convex hull has 2n-2-h triangles
+		if want := 2*len(points) - 2 - len(ConvexHull(points)); len(triangles) != want {
+			t.Errorf("seed %d: got %d triangles, want %d", seed, len(triangles), want)
+		}
+		checkDelaunay(t, "random", points, triangles)
+	}
+
+	if _, err := DelaunayTriangulate(square[:2]); err == nil {
+		t.Errorf("no error for 2 points")
+	}
+	if _, err := DelaunayTriangulate([]T{{1, 1}, {1, 1}, {1, 1}}); err == nil {
+		t.Errorf("no error for 3 equal points")
+	}
+	if _, err := DelaunayTriangulate([]T{{0, 0}, {1, 1}, {2, 2}, {3, 3}}); err == nil {
+		t.Errorf("no error for collinear points")
+	}
+}
@@ -0,0 +1,59 @@
+package vec2
+
+import (
+	"sort"
+)
+
+// ConvexHull returns the vertices of the convex hull of points
+// in counter-clockwise order using Andrew's monotone chain algorithm.
+// Duplicate points and points lying on the edges of the hull are omitted.
+// If all points are collinear, the two extreme points are returned,
+// a single distinct point is returned as is.
+// The points slice itself is not modified.
+func ConvexHull(points []T) []T {
+	sorted := make([]T, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i][0] != sorted[j][0] {
+			return sorted[i][0] < sorted[j][0]
+		}
+		return sorted[i][1] < sorted[j][1]
+	})
+	// remove duplicates
+	n := 0
+	for i := range sorted {
+		if n == 0 || sorted[i] != sorted[n-1] {
+			sorted[n] = sorted[i]
+			n++
+		}
+	}
+	sorted = sorted[:n]
+	if n < 3 {
+		return sorted
+	}
+
+	hull := make([]T, 0, 2*n)
+	// lower hull
+	for i := 0; i < n; i++ {
+		for len(hull) >= 2 && turn(&hull[len(hull)-2], &hull[len(hull)-1], &sorted[i]) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, sorted[i])
+	}
+	// upper hull
+	lower := len(hull) + 1
+	for i := n - 2; i >= 0; i-- {
+		for len(hull) >= lower && turn(&hull[len(hull)-2], &hull[len(hull)-1], &sorted[i]) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, sorted[i])
+	}
+	// the last point is the first one again
+	return hull[:len(hull)-1]
+}
+
+// turn returns the z component of the cross product of b-a and c-a,
+// positive for a counter-clockwise turn from a over b to c.
+func turn(a, b, c *T) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
@@ -0,0 +1,29 @@
+package vec2
+
+import (
+	"testing"
+)
+
+func TestConvexHull(t *testing.T) {
+	points := []T{{0.5, 0.5}, {0, 0}, {1, 1}, {0.2, 0.7}, {1, 0}, {0, 1}, {0.5, 0}, {1, 1}, {0.9, 0.1}}
+	hull := ConvexHull(points)
+	want := []T{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+	if len(hull) != len(want) {
+		t.Fatalf("hull is %v, want %v", hull, want)
+	}
+	for i := range want {
+		if hull[i] != want[i] {
+			t.Fatalf("hull is %v, want %v", hull, want)
+		}
+	}
+
+	collinear := []T{{2, 2}, {0, 0}, {1, 1}, {3, 3}, {1, 1}}
+	hull = ConvexHull(collinear)
+	if len(hull) != 2 || hull[0] != (T{0, 0}) || hull[1] != (T{3, 3}) {
+		t.Errorf("hull of collinear points is %v, want [[0 0] [3 3]]", hull)
+	}
+
+	if hull = ConvexHull([]T{{1, 2}, {1, 2}}); len(hull) != 1 {
+		t.Errorf("hull of duplicate points is %v, want a single point", hull)
+	}
+}
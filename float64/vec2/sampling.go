@@ -0,0 +1,135 @@
+package vec2
+
+import (
+	"math"
+	"math/rand"
+)
+
+// PoissonDiskSample returns randomly distributed points in the rectangle
+// from (0,0) to (width,height) that are at least minDistance apart,
+// using Bridson's algorithm with 30 candidates per active point.
+// The result is a maximal blue noise distribution with approximately
+// 0.65*width*height/minDistance² points.
+func PoissonDiskSample(width, height, minDistance float64, rng *rand.Rand) []T {
+	const candidates = 30
+	if width <= 0 || height <= 0 || minDistance <= 0 {
+		return nil
+	}
+	// every grid cell can hold at most one point
+	cellSize := minDistance / math.Sqrt2
+	cols := int(math.Ceil(width / cellSize))
+	rows := int(math.Ceil(height / cellSize))
+	grid := make([]int, cols*rows)
+	for i := range grid {
+		grid[i] = -1
+	}
+
+	first := T{rng.Float64() * width, rng.Float64() * height}
+	points := []T{first}
+	grid[poissonCell(&first, cellSize, cols)] = 0
+	active := []int{0}
+
+	for len(active) > 0 {
+		a := rng.Intn(len(active))
+		center := points[active[a]]
+		found := false
+		for k := 0; k < candidates; k++ {
+			// uniform in the annulus between minDistance and 2*minDistance
+			r := minDistance * math.Sqrt(1+3*rng.Float64())
+			angle := 2 * math.Pi * rng.Float64()
+			p := T{center[0] + r*math.Cos(angle), center[1] + r*math.Sin(angle)}
+			if p[0] < 0 || p[1] < 0 || p[0] >= width || p[1] >= height {
+				continue
+			}
+			if !poissonIsFree(&p, points, grid, cellSize, cols, rows, minDistance) {
+				continue
+			}
+			grid[poissonCell(&p, cellSize, cols)] = len(points)
+			active = append(active, len(points))
+			points = append(points, p)
+			found = true
+			break
+		}
+		if !found {
+			active[a] = active[len(active)-1]
+			active = active[:len(active)-1]
+		}
+	}
+	return points
+}
+
+func poissonCell(p *T, cellSize float64, cols int) int {
+	return int(p[1]/cellSize)*cols + int(p[0]/cellSize)
+}
+
+func poissonIsFree(p *T, points []T, grid []int, cellSize float64, cols, rows int, minDistance float64) bool {
+	cx := int(p[0] / cellSize)
+	cy := int(p[1] / cellSize)
+	minDistSqr := minDistance * minDistance
+	for y := cy - 2; y <= cy+2; y++ {
+		if y < 0 || y >= rows {
+			continue
+		}
+		for x := cx - 2; x <= cx+2; x++ {
+			if x < 0 || x >= cols {
+				continue
+			}
+			if i := grid[y*cols+x]; i >= 0 {
+				d := Sub(p, &points[i])
+				if d.LengthSqr() < minDistSqr {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// JitteredGrid returns samplesPerAxis² stratified samples in [0,1)².
+// The unit square is divided into samplesPerAxis by samplesPerAxis cells
+// and every cell gets one sample at a random position within the cell.
+// The samples are ordered row by row, starting at the cell at the origin.
+func JitteredGrid(samplesPerAxis int, rng *rand.Rand) []T {
+	if samplesPerAxis <= 0 {
+		return nil
+	}
+	cellSize := 1 / float64(samplesPerAxis)
+	samples := make([]T, 0, samplesPerAxis*samplesPerAxis)
+	for y := 0; y < samplesPerAxis; y++ {
+		for x := 0; x < samplesPerAxis; x++ {
+			samples = append(samples, T{
+				(float64(x) + rng.Float64()) * cellSize,
+				(float64(y) + rng.Float64()) * cellSize,
+			})
+		}
+	}
+	return samples
+}
+
+// HaltonSequence returns the point with the given index of the two dimensional
+// Halton low-discrepancy sequence with the bases baseX and baseY in [0,1)².
+// The bases should be coprime, commonly 2 and 3.
+// Index 0 is the origin, so sequences usually start at index 1.
+// To jitter a projection per frame for temporal anti-aliasing, map the point
+// to a subpixel offset around the pixel center and pass it to mat4.T.Jitter:
+//
+//	h := vec2.HaltonSequence(frame%8+1, 2, 3)
+//	jittered := proj.Jitter((2*h[0]-1)/width, (2*h[1]-1)/height)
+func HaltonSequence(index int, baseX, baseY int) T {
+	return T{radicalInverse(index, baseX), radicalInverse(index, baseY)}
+}
+
+// radicalInverse mirrors the digits of index in base at the decimal point.
+// Negative indices and bases below 2 result in zero.
+func radicalInverse(index, base int) float64 {
+	if base < 2 {
+		return 0
+	}
+	result := 0.0
+	f := 1 / float64(base)
+	for scale := f; index > 0; index /= base {
+		result += float64(index%base) * scale
+		scale *= f
+	}
+	return result
+}
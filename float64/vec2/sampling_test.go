@@ -0,0 +1,67 @@
+package vec2
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPoissonDiskSample(t *testing.T) {
+	const width, height, minDistance = 20.0, 10.0, 0.5
+	points := PoissonDiskSample(width, height, minDistance, rand.New(rand.NewSource(1)))
+	if estimate := 0.65 * width * height / (minDistance * minDistance); float64(len(points)) < estimate*0.8 {
+		t.Fatalf("only %d points, expected about %v", len(points), estimate)
+	}
+	for i := range points {
+		p := &points[i]
+		if p[0] < 0 || p[1] < 0 || p[0] >= width || p[1] >= height {
+			t.Fatalf("point %v is out of bounds", p)
+		}
+		for j := i + 1; j < len(points); j++ {
+			d := Sub(p, &points[j])
+			if d.Length() < minDistance {
+				t.Fatalf("points %v and %v are closer than %v", p, points[j], minDistance)
+			}
+		}
+	}
+}
+
+func TestJitteredGrid(t *testing.T) {
+	const n = 7
+	samples := JitteredGrid(n, rand.New(rand.NewSource(1)))
+	if len(samples) != n*n {
+		t.Fatalf("got %d samples, want %d", len(samples), n*n)
+	}
+	for i, s := range samples {
+		x, y := i%n, i/n
+		if int(s[0]*n) != x || int(s[1]*n) != y {
+			t.Errorf("sample %d at %v is not in the cell %d, %d", i, s, x, y)
+		}
+	}
+}
+
+func TestHaltonSequence(t *testing.T) {
+	want := []T{
+		{0, 0},
+		{1.0 / 2, 1.0 / 3},
+		{1.0 / 4, 2.0 / 3},
+		{3.0 / 4, 1.0 / 9},
+		{1.0 / 8, 4.0 / 9},
+		{5.0 / 8, 7.0 / 9},
+		{3.0 / 8, 2.0 / 9},
+		{7.0 / 8, 5.0 / 9},
+		{1.0 / 16, 8.0 / 9},
+	}
+	for i, w := range want {
+		h := HaltonSequence(i, 2, 3)
+		if math.Abs(h[0]-w[0]) > 1e-15 || math.Abs(h[1]-w[1]) > 1e-15 {
+			t.Errorf("HaltonSequence(%d, 2, 3) is %v, want %v", i, h, w)
+		}
+	}
+	for i := 1; i < 1000; i++ {
+		h := HaltonSequence(i, 2, 3)
+		if h[0] < 0 || h[0] >= 1 || h[1] < 0 || h[1] >= 1 {
+			t.Fatalf("HaltonSequence(%d, 2, 3) is %v, outside [0,1)", i, h)
+		}
+	}
+}
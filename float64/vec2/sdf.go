@@ -0,0 +1,35 @@
+package vec2
+
+import (
+	"math"
+)
+
+// SDFCircle returns the signed distance of p from the circle
+// with the given radius around the origin, negative inside.
+func SDFCircle(p *T, radius float64) float64 {
+	return p.Length() - radius
+}
+
+// SDFBox returns the signed distance of p from the axis aligned box
+// with the half edge lengths halfSize around the origin, negative inside.
+func SDFBox(p, halfSize *T) float64 {
+	dx := math.Abs(p[0]) - halfSize[0]
+	dy := math.Abs(p[1]) - halfSize[1]
+	outside := T{math.Max(dx, 0), math.Max(dy, 0)}
+	inside := math.Min(math.Max(dx, dy), 0)
+	return outside.Length() + inside
+}
+
+// SDFSegment returns the distance of p from the line segment from a to b.
+// A segment has no inside, so the result is never negative.
+func SDFSegment(p, a, b *T) float64 {
+	pa := Sub(p, a)
+	ba := Sub(b, a)
+	h := 0.0
+	if l := ba.LengthSqr(); l > 0 {
+		h = math.Max(0, math.Min(1, Dot(&pa, &ba)/l))
+	}
+	ba.Scale(h)
+	pa.Sub(&ba)
+	return pa.Length()
+}
@@ -0,0 +1,43 @@
+package vec2
+
+import (
+	"math"
+	"testing"
+)
+
+const EPSILON = 0.000001
+
+func TestSDFCircle(t *testing.T) {
+	for _, c := range []struct {
+		p    T
+		want float64
+	}{{T{0, 0}, -2}, {T{0, 2}, 0}, {T{3, 4}, 3}} {
+		if d := SDFCircle(&c.p, 2); math.Abs(d-c.want) > EPSILON {
+			t.Errorf("distance of %v is %v, want %v", c.p, d, c.want)
+		}
+	}
+}
+
+func TestSDFBox(t *testing.T) {
+	half := T{2, 1}
+	for _, c := range []struct {
+		p    T
+		want float64
+	}{{T{0, 0}, -1}, {T{1.5, 0}, -0.5}, {T{2, 0.5}, 0}, {T{5, 0}, 3}, {T{5, 5}, 5}} {
+		if d := SDFBox(&c.p, &half); math.Abs(d-c.want) > EPSILON {
+			t.Errorf("distance of %v is %v, want %v", c.p, d, c.want)
+		}
+	}
+}
+
+func TestSDFSegment(t *testing.T) {
+	a, b := T{-1, 0}, T{1, 0}
+	for _, c := range []struct {
+		p    T
+		want float64
+	}{{T{0, 0}, 0}, {T{0.5, 0}, 0}, {T{0, 2}, 2}, {T{4, 4}, 5}} {
+		if d := SDFSegment(&c.p, &a, &b); math.Abs(d-c.want) > EPSILON {
+			t.Errorf("distance of %v is %v, want %v", c.p, d, c.want)
+		}
+	}
+}
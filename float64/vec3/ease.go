@@ -0,0 +1,67 @@
+package vec3
+
+import (
+	"math"
+)
+
+// EaseFunc maps the linear interpolation parameter t (0,1) to an eased parameter.
+// Easing functions return 0 for t = 0 and 1 for t = 1.
+type EaseFunc func(t float64) float64
+
+// LerpEase interpolates between a and b at the parameter t (0,1) mapped by ease.
+func LerpEase(a, b *T, t float64, ease EaseFunc) T {
+	return Interpolate(a, b, ease(t))
+}
+
+// EaseLinear returns t unchanged.
+func EaseLinear(t float64) float64 {
+	return t
+}
+
+// EaseInQuad starts slow and accelerates quadratically.
+func EaseInQuad(t float64) float64 {
+	return t * t
+}
+
+// EaseOutQuad starts fast and decelerates quadratically.
+func EaseOutQuad(t float64) float64 {
+	return t * (2 - t)
+}
+
+// EaseInOutQuad accelerates quadratically until t = 0.5 and decelerates afterwards.
+func EaseInOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return -1 + (4-2*t)*t
+}
+
+// EaseInCubic starts slow and accelerates cubically.
+func EaseInCubic(t float64) float64 {
+	return t * t * t
+}
+
+// EaseOutCubic starts fast and decelerates cubically.
+func EaseOutCubic(t float64) float64 {
+	t1 := t - 1
+	return t1*t1*t1 + 1
+}
+
+// EaseInOutCubic accelerates cubically until t = 0.5 and decelerates afterwards.
+func EaseInOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	t1 := 2*t - 2
+	return 0.5*t1*t1*t1 + 1
+}
+
+// EaseInOutSine accelerates and decelerates following a cosine curve.
+func EaseInOutSine(t float64) float64 {
+	return 0.5 * (1 - math.Cos(math.Pi*t))
+}
+
+// SmoothStep is the Hermite easing 3t² - 2t³ with zero slope at both ends.
+func SmoothStep(t float64) float64 {
+	return t * t * (3 - 2*t)
+}
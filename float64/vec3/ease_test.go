@@ -0,0 +1,38 @@
+package vec3
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLerpEase(t *testing.T) {
+	a := T{0, 1, 2}
+	b := T{4, -1, 6}
+	eases := map[string]EaseFunc{
+		"EaseLinear":     EaseLinear,
+		"EaseInQuad":     EaseInQuad,
+		"EaseOutQuad":    EaseOutQuad,
+		"EaseInOutQuad":  EaseInOutQuad,
+		"EaseInCubic":    EaseInCubic,
+		"EaseOutCubic":   EaseOutCubic,
+		"EaseInOutCubic": EaseInOutCubic,
+		"EaseInOutSine":  EaseInOutSine,
+		"SmoothStep":     SmoothStep,
+	}
+	for name, ease := range eases {
+		if got := LerpEase(&a, &b, 0, ease); Distance(&got, &a) > EPSILON {
+			t.Errorf("%s at t=0 is %v, want %v", name, got, a)
+		}
+		if got := LerpEase(&a, &b, 1, ease); Distance(&got, &b) > EPSILON {
+			t.Errorf("%s at t=1 is %v, want %v", name, got, b)
+		}
+	}
+	linear := Interpolate(&a, &b, 0.25)
+	eased := LerpEase(&a, &b, 0.25, EaseInQuad)
+	if Distance(&linear, &eased) < 0.1 {
+		t.Errorf("EaseInQuad at t=0.25 is %v, too close to linear %v", eased, linear)
+	}
+	if got := EaseInOutCubic(0.5); math.Abs(got-0.5) > EPSILON {
+		t.Errorf("EaseInOutCubic(0.5) is %v, want 0.5", got)
+	}
+}
@@ -0,0 +1,144 @@
+package vec3
+
+import (
+	"errors"
+	"math"
+)
+
+// FitLine returns the least squares line through points
+// as the centroid of the points and the unit direction of the line.
+// The direction is the eigenvector of the largest eigenvalue
+// of the covariance matrix of the points.
+// An error is returned for less than 2 points or if all points are equal.
+func FitLine(points []T) (point T, direction T, err error) {
+	if len(points) < 2 {
+		return Zero, Zero, errors.New("vec3.FitLine: at least 2 points required")
+	}
+	for i := range points {
+		point.Add(&points[i])
+	}
+	point.Scale(1 / float64(len(points)))
+
+	// covariance matrix as columns
+	var cov [3]T
+	for i := range points {
+		d := Sub(&points[i], &point)
+		for col := range cov {
+			for row := range cov[col] {
+				cov[col][row] += d[col] * d[row]
+			}
+		}
+	}
+	values, vectors := symmetricEigen(cov)
+	if values[0] <= 0 {
+		return point, Zero, errors.New("vec3.FitLine: all points are equal")
+	}
+	return point, vectors[0], nil
+}
+
+// symmetricEigen returns the eigenvalues in descending order and the
+// unit length eigenvectors of the symmetric 3x3 matrix given as columns
+// using the cyclic Jacobi method, like mat3.T.SymmetricEigen.
+func symmetricEigen(a [3]T) (values T, vectors [3]T) {
+	v := [3]T{UnitX, UnitY, UnitZ}
+	for sweep := 0; sweep < 50; sweep++ {
+		off := a[0][1]*a[0][1] + a[0][2]*a[0][2] + a[1][2]*a[1][2]
+		if off < 1e-30 {
+			break
+		}
+		for p := 0; p < 3; p++ {
+			for q := p + 1; q < 3; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				for k := 0; k < 3; k++ {
+					akp, akq := a[k][p], a[k][q]
+					a[k][p] = c*akp - s*akq
+					a[k][q] = s*akp + c*akq
+				}
+				for k := 0; k < 3; k++ {
+					apk, aqk := a[p][k], a[q][k]
+					a[p][k] = c*apk - s*aqk
+					a[q][k] = s*apk + c*aqk
+				}
+				// the eigenvectors are the columns v[p] and v[q]
+				for k := 0; k < 3; k++ {
+					vkp, vkq := v[p][k], v[q][k]
+					v[p][k] = c*vkp - s*vkq
+					v[q][k] = s*vkp + c*vkq
+				}
+			}
+		}
+	}
+	for i := 0; i < 3; i++ {
+		values[i] = a[i][i]
+	}
+	vectors = v
+	// sort descending by eigenvalue
+	for i := 0; i < 2; i++ {
+		for j := i + 1; j < 3; j++ {
+			if values[j] > values[i] {
+				values[i], values[j] = values[j], values[i]
+				vectors[i], vectors[j] = vectors[j], vectors[i]
+			}
+		}
+	}
+	return values, vectors
+}
+
+// FitSphere returns the center and radius of the algebraic least squares sphere
+// through points, which minimizes the sum of (|p-center|² - radius²)².
+// With the points relative to their centroid the linear system for the center
+// decouples from the radius into the 3x3 covariance system solved by Cramer's rule.
+// An error is returned for less than 4 points or if the points are coplanar.
+func FitSphere(points []T) (center T, radius float64, err error) {
+	if len(points) < 4 {
+		return Zero, 0, errors.New("vec3.FitSphere: at least 4 points required")
+	}
+	var mean T
+	for i := range points {
+		mean.Add(&points[i])
+	}
+	mean.Scale(1 / float64(len(points)))
+
+	// covariance matrix as columns and the right-hand side sum of q*|q|²/2
+	var cov [3]T
+	var rhs T
+	meanSqr := 0.0
+	for i := range points {
+		q := Sub(&points[i], &mean)
+		lengthSqr := q.LengthSqr()
+		meanSqr += lengthSqr
+		for col := range cov {
+			for row := range cov[col] {
+				cov[col][row] += q[col] * q[row]
+			}
+		}
+		q.Scale(0.5 * lengthSqr)
+		rhs.Add(&q)
+	}
+	meanSqr /= float64(len(points))
+
+	cross := Cross(&cov[1], &cov[2])
+	det := Dot(&cov[0], &cross)
+	trace := cov[0][0] + cov[1][1] + cov[2][2]
+	if math.Abs(det) <= 1e-12*trace*trace*trace {
+		return Zero, 0, errors.New("vec3.FitSphere: points are coplanar")
+	}
+	for i := range center {
+		m := cov
+		m[i] = rhs
+		cross := Cross(&m[1], &m[2])
+		center[i] = Dot(&m[0], &cross) / det
+	}
+	radius = math.Sqrt(meanSqr + center.LengthSqr())
+	center.Add(&mean)
+	return center, radius, nil
+}
@@ -0,0 +1,100 @@
+package vec3
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestFitLine(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	origin := T{1, -2, 3}
+	dir := T{2, 1, -0.5}
+	dir.Normalize()
+	points := make([]T, 200)
+	for i := range points {
+		p := dir.Scaled(float64(i-100) * 0.1)
+		p.Add(&origin)
+		p.Add(&T{rng.NormFloat64() * 0.01, rng.NormFloat64() * 0.01, rng.NormFloat64() * 0.01})
+		points[i] = p
+	}
+	point, direction, err := FitLine(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(direction.Length()-1) > EPSILON {
+		t.Errorf("direction %v is not unit length", direction)
+	}
+	if cos := math.Abs(Dot(&direction, &dir)); cos < 0.9999 {
+		t.Errorf("direction %v deviates from %v", direction, dir)
+	}
+	// the point must be on the original line
+	d := Sub(&point, &origin)
+	along := dir.Scaled(Dot(&d, &dir))
+	if off := Distance(&d, &along); off > 0.01 {
+		t.Errorf("point %v is %v away from the line", point, off)
+	}
+
+	// the longest covariance column is perpendicular to the dominant direction
+	s := math.Sqrt(0.5)
+	points = []T{
+		{s, s, 0}, {-s, -s, 0},
+		{0.95 * s, -0.95 * s, 0}, {-0.95 * s, 0.95 * s, 0},
+		{0, 0, 0.99}, {0, 0, -0.99},
+	}
+	_, direction, err = FitLine(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (T{s, s, 0}); math.Abs(Dot(&direction, &want)) < 1-1e-12 {
+		t.Errorf("direction is %v, want ±%v", direction, want)
+	}
+
+	if _, _, err := FitLine(points[:1]); err == nil {
+		t.Errorf("no error for a single point")
+	}
+	if _, _, err := FitLine([]T{origin, origin}); err == nil {
+		t.Errorf("no error for equal points")
+	}
+}
+
+func TestFitSphere(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	wantCenter := T{1, -2, 3}
+	const wantRadius = 2.5
+	points := make([]T, 300)
+	for i := range points {
+		dir := T{rng.NormFloat64(), rng.NormFloat64(), rng.NormFloat64()}
+		dir.Normalize()
+		p := dir.Scaled(wantRadius + rng.NormFloat64()*0.01)
+		points[i] = *p.Add(&wantCenter)
+	}
+	center, radius, err := FitSphere(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := Distance(&center, &wantCenter); d > 0.01 {
+		t.Errorf("center is %v, want %v", center, wantCenter)
+	}
+	if math.Abs(radius-wantRadius) > 0.01 {
+		t.Errorf("radius is %v, want %v", radius, wantRadius)
+	}
+
+	// exact fit of 4 points on a cap of the unit sphere
+	points4 := []T{{0, 0, 1}, {0.6, 0, 0.8}, {0, 0.6, 0.8}, {-0.6, 0, 0.8}}
+	center, radius, err = FitSphere(points4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if center.Length() > 1e-9 || math.Abs(radius-1) > 1e-9 {
+		t.Errorf("cap sphere is %v with radius %v, want the unit sphere", center, radius)
+	}
+
+	if _, _, err := FitSphere(points[:3]); err == nil {
+		t.Error("no error for 3 points")
+	}
+	coplanar := []T{{0, 0, 1}, {1, 0, 1}, {0, 1, 1}, {1, 1, 1}, {0.5, 0.3, 1}}
+	if _, _, err := FitSphere(coplanar); err == nil {
+		t.Error("no error for coplanar points")
+	}
+}
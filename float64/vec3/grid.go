@@ -0,0 +1,121 @@
+package vec3
+
+import (
+	"math"
+)
+
+// Cell returns the integer coordinates of the grid cell with the edge length cellSize
+// that contains the vector.
+func (vec *T) Cell(cellSize float64) [3]int64 {
+	return [3]int64{
+		int64(math.Floor(vec[0] / cellSize)),
+		int64(math.Floor(vec[1] / cellSize)),
+		int64(math.Floor(vec[2] / cellSize)),
+	}
+}
+
+// NeighborCells returns the integer coordinates of all grid cells
+// within radius cells of the cell containing the vector,
+// which is a block of (2*radius+1)³ cells including the cell itself.
+// The cells are ordered with X varying fastest, then Y, then Z,
+// starting at the cell with the smallest coordinates.
+func (vec *T) NeighborCells(cellSize float64, radius int) [][3]int64 {
+	if radius < 0 {
+		return nil
+	}
+	center := vec.Cell(cellSize)
+	r := int64(radius)
+	side := 2*radius + 1
+	cells := make([][3]int64, 0, side*side*side)
+	for z := center[2] - r; z <= center[2]+r; z++ {
+		for y := center[1] - r; y <= center[1]+r; y++ {
+			for x := center[0] - r; x <= center[0]+r; x++ {
+				cells = append(cells, [3]int64{x, y, z})
+			}
+		}
+	}
+	return cells
+}
+
+// OctreeChildIndex returns the index 0 to 7 of the octree child cell
+// around center that contains the vector.
+// Bit 0 of the index is set if X >= center X, bit 1 if Y >= center Y
+// and bit 2 if Z >= center Z.
+func (vec *T) OctreeChildIndex(center *T) int {
+	index := 0
+	if vec[0] >= center[0] {
+		index |= 1
+	}
+	if vec[1] >= center[1] {
+		index |= 2
+	}
+	if vec[2] >= center[2] {
+		index |= 4
+	}
+	return index
+}
+
+// GridTraversal enumerates the cells of a regular grid that a ray
+// passes through in the order they are visited, using the
+// incremental algorithm of Amanatides and Woo.
+// Create it with NewGridTraversal.
+type GridTraversal struct {
+	// MaxT limits the traversal to the cells the ray enters
+	// at a ray parameter t <= MaxT. It is +Inf by default.
+	MaxT float64
+
+	cell    [3]int64
+	step    [3]int64
+	tMax    T
+	tDelta  T
+	started bool
+}
+
+// NewGridTraversal returns a GridTraversal for the ray
+// origin + dir*t with t >= 0 through the grid with the cell edge length cellSize.
+// The cell coordinates are the same as returned by T.Cell.
+func NewGridTraversal(origin, dir *T, cellSize float64) *GridTraversal {
+	g := &GridTraversal{
+		MaxT: math.Inf(1),
+		cell: origin.Cell(cellSize),
+	}
+	for i := 0; i < 3; i++ {
+		switch {
+		case dir[i] > 0:
+			g.step[i] = 1
+			g.tMax[i] = (float64(g.cell[i]+1)*cellSize - origin[i]) / dir[i]
+			g.tDelta[i] = cellSize / dir[i]
+		case dir[i] < 0:
+			g.step[i] = -1
+			g.tMax[i] = (float64(g.cell[i])*cellSize - origin[i]) / dir[i]
+			g.tDelta[i] = -cellSize / dir[i]
+		default:
+			g.tMax[i] = math.Inf(1)
+			g.tDelta[i] = math.Inf(1)
+		}
+	}
+	return g
+}
+
+// Next returns the next cell along the ray, starting with the cell containing the origin.
+// The bool result is false when the traversal has passed MaxT
+// or the ray direction is zero and the origin cell has already been returned.
+func (g *GridTraversal) Next() ([3]int64, bool) {
+	if !g.started {
+		g.started = true
+		return g.cell, true
+	}
+	axis := 0
+	if g.tMax[1] < g.tMax[axis] {
+		axis = 1
+	}
+	if g.tMax[2] < g.tMax[axis] {
+		axis = 2
+	}
+	if math.IsInf(g.tMax[axis], 1) || g.tMax[axis] > g.MaxT {
+		return g.cell, false
+	}
+	g.cell[axis] += g.step[axis]
+	g.tMax[axis] += g.tDelta[axis]
+	return g.cell, true
+}
@@ -0,0 +1,86 @@
+package vec3
+
+import (
+	"testing"
+)
+
+func TestNeighborCells(t *testing.T) {
+	p := T{2.5, -0.5, 7.9}
+	if got, want := p.Cell(2), [3]int64{1, -1, 3}; got != want {
+		t.Errorf("Cell is %v, want %v", got, want)
+	}
+	cells := p.NeighborCells(2, 1)
+	if len(cells) != 27 {
+		t.Fatalf("got %d cells, want 27", len(cells))
+	}
+	if want := [3]int64{0, -2, 2}; cells[0] != want {
+		t.Errorf("first cell is %v, want %v", cells[0], want)
+	}
+	if want := [3]int64{1, -1, 3}; cells[13] != want {
+		t.Errorf("center cell is %v, want %v", cells[13], want)
+	}
+	if want := [3]int64{2, 0, 4}; cells[26] != want {
+		t.Errorf("last cell is %v, want %v", cells[26], want)
+	}
+	seen := make(map[[3]int64]bool)
+	for _, c := range cells {
+		if seen[c] {
+			t.Errorf("cell %v returned twice", c)
+		}
+		seen[c] = true
+	}
+}
+
+func TestOctreeChildIndex(t *testing.T) {
+	center := T{1, 2, 3}
+	for i := 0; i < 8; i++ {
+		p := center
+		for axis := 0; axis < 3; axis++ {
+			if i&(1<<uint(axis)) != 0 {
+				p[axis] += 0.5
+			} else {
+				p[axis] -= 0.5
+			}
+		}
+		if got := p.OctreeChildIndex(&center); got != i {
+			t.Errorf("OctreeChildIndex of %v is %d, want %d", p, got, i)
+		}
+	}
+	if got := center.OctreeChildIndex(&center); got != 7 {
+		t.Errorf("OctreeChildIndex of the center is %d, want 7", got)
+	}
+}
+
+func TestGridTraversal(t *testing.T) {
+	origin := T{0.5, 2.5, -1.5}
+	dir := T{-1, 0, 0}
+	g := NewGridTraversal(&origin, &dir, 1)
+	g.MaxT = 3
+	want := [][3]int64{{0, 2, -2}, {-1, 2, -2}, {-2, 2, -2}, {-3, 2, -2}}
+	for i, w := range want {
+		cell, ok := g.Next()
+		if !ok || cell != w {
+			t.Fatalf("cell %d is %v (ok=%v), want %v", i, cell, ok, w)
+		}
+	}
+	if cell, ok := g.Next(); ok {
+		t.Errorf("traversal continued past MaxT to %v", cell)
+	}
+
+	origin = T{0.5, 0.5, 0.5}
+	dir = T{1, 0.5, 0}
+	g = NewGridTraversal(&origin, &dir, 1)
+	want = [][3]int64{{0, 0, 0}, {1, 0, 0}, {1, 1, 0}, {2, 1, 0}, {3, 1, 0}, {3, 2, 0}}
+	for i, w := range want {
+		cell, ok := g.Next()
+		if !ok || cell != w {
+			t.Fatalf("diagonal cell %d is %v (ok=%v), want %v", i, cell, ok, w)
+		}
+	}
+
+	g = NewGridTraversal(&origin, &Zero, 1)
+	g.Next()
+	if _, ok := g.Next(); ok {
+		t.Error("traversal with zero direction must stop after the origin cell")
+	}
+}
@@ -0,0 +1,61 @@
+package vec3
+
+import (
+	"math"
+)
+
+// Hash returns a deterministic pseudo random value in [0, 1) for the position p.
+// The bits of the three components are mixed with the SplitMix64 finalizer,
+// so equal positions always result in the same value while nearby positions
+// result in uncorrelated values. Negative and positive zero hash equally.
+func Hash(p *T) float64 {
+	var h uint64 = 0x9e3779b97f4a7c15
+	for _, c := range p {
+		// adding zero turns negative zero into positive zero
+		h = mix64(h ^ math.Float64bits(c+0))
+	}
+	return float64(h>>11) / (1 << 53)
+}
+
+// MortonCode quantizes vec to bits bits per axis within the box
+// spanned by boundsMin and boundsMax and interleaves the bits of the
+// three axes into a Z-order curve index with x in the lowest bit.
+// Points outside the bounds are clamped to them.
+// A 64-bit code holds at most 21 bits per axis, so bits is clamped to [1, 21].
+func (vec *T) MortonCode(boundsMin, boundsMax *T, bits int) uint64 {
+	if bits < 1 {
+		bits = 1
+	} else if bits > 21 {
+		bits = 21
+	}
+	cells := float64(uint64(1) << uint(bits))
+	var quantized [3]uint64
+	for i := range vec {
+		extent := boundsMax[i] - boundsMin[i]
+		t := 0.0
+		if extent > 0 {
+			t = (vec[i] - boundsMin[i]) / extent
+		}
+		q := math.Floor(t * cells)
+		if q < 0 || math.IsNaN(q) {
+			q = 0
+		} else if q > cells-1 {
+			q = cells - 1
+		}
+		quantized[i] = uint64(q)
+	}
+	var code uint64
+	for b := 0; b < bits; b++ {
+		for i, q := range quantized {
+			code |= (q >> uint(b) & 1) << uint(3*b+i)
+		}
+	}
+	return code
+}
+
+func mix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
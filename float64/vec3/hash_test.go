@@ -0,0 +1,95 @@
+package vec3
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	p := T{1.5, -2, 3.25}
+	if a, b := Hash(&p), Hash(&T{1.5, -2, 3.25}); a != b {
+		t.Errorf("hash is not deterministic: %v != %v", a, b)
+	}
+	if q := (T{3.25, -2, 1.5}); Hash(&p) == Hash(&q) {
+		t.Errorf("hash does not depend on the component order")
+	}
+	if Hash(&T{0, 0, 0}) != Hash(&T{math.Copysign(0, -1), 0, 0}) {
+		t.Errorf("negative zero hashes differently")
+	}
+
+	const n = 20
+	var buckets [10]int
+	sum := 0.0
+	for x := 0; x < n; x++ {
+		for y := 0; y < n; y++ {
+			for z := 0; z < n; z++ {
+				h := Hash(&T{float64(x), float64(y), float64(z)})
+				if h < 0 || h >= 1 {
+					t.Fatalf("hash %v out of [0, 1)", h)
+				}
+				buckets[int(h*10)]++
+				sum += h
+			}
+		}
+	}
+	count := n * n * n
+	if mean := sum / float64(count); mean < 0.48 || mean > 0.52 {
+		t.Errorf("mean of hashes is %v, want about 0.5", mean)
+	}
+	for i, b := range buckets {
+		if b < count/10*9/10 || b > count/10*11/10 {
+			t.Errorf("bucket %d has %d of %d hashes", i, b, count)
+		}
+	}
+}
+
+func TestMortonCode(t *testing.T) {
+	boundsMin := T{-1, -1, -1}
+	boundsMax := T{1, 1, 1}
+
+	if c := boundsMin.MortonCode(&boundsMin, &boundsMax, 10); c != 0 {
+		t.Errorf("code of the minimum is %d, want 0", c)
+	}
+	if c := boundsMax.MortonCode(&boundsMin, &boundsMax, 10); c != 1<<30-1 {
+		t.Errorf("code of the maximum is %d, want %d", c, 1<<30-1)
+	}
+	if c := boundsMax.MortonCode(&boundsMin, &boundsMax, 30); c != 1<<63-1 {
+		t.Errorf("code with clamped bits is %d, want %d", c, uint64(1<<63-1))
+	}
+	outside := T{5, -5, 0.5}
+	clamped := T{1, -1, 0.5}
+	if a, b := outside.MortonCode(&boundsMin, &boundsMax, 8), clamped.MortonCode(&boundsMin, &boundsMax, 8); a != b {
+		t.Errorf("outside point code %d != clamped point code %d", a, b)
+	}
+
+	// bit 0 is x, bit 1 is y, bit 2 is z
+	x := T{0.75, -0.75, -0.75}
+	y := T{-0.75, 0.75, -0.75}
+	z := T{-0.75, -0.75, 0.75}
+	for i, v := range []T{x, y, z} {
+		if c := v.MortonCode(&boundsMin, &boundsMax, 1); c != 1<<uint(i) {
+			t.Errorf("1-bit code of axis %d is %d, want %d", i, c, 1<<uint(i))
+		}
+	}
+
+	// nearby points in the same coarse cell share the high bits
+	const bits = 16
+	p := T{0.3, -0.2, 0.1}
+	q := T{0.3 + 1e-4, -0.2 + 1e-4, 0.1}
+	far := T{-0.3, 0.2, -0.1}
+	cp := p.MortonCode(&boundsMin, &boundsMax, bits)
+	cq := q.MortonCode(&boundsMin, &boundsMax, bits)
+	cf := far.MortonCode(&boundsMin, &boundsMax, bits)
+	diff := func(a, b uint64) uint64 {
+		if a > b {
+			return a - b
+		}
+		return b - a
+	}
+	if diff(cp, cq) >= diff(cp, cf) {
+		t.Errorf("close points have codes %d and %d, far point %d", cp, cq, cf)
+	}
+	if cp>>(3*8) != cq>>(3*8) {
+		t.Errorf("close points %d and %d do not share the upper code bits", cp, cq)
+	}
+}
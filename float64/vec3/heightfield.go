@@ -0,0 +1,74 @@
+package vec3
+
+import (
+	"math"
+)
+
+// HeightField is a regular grid of heights in the XZ plane with Y pointing up.
+// The sample at column x and row z is Heights[z*Width+x] and lies at
+// Origin + (x*CellSize, Heights[z*Width+x], z*CellSize).
+type HeightField struct {
+	Origin   T
+	CellSize float64
+	Width    int
+	Depth    int
+	Heights  []float64
+}
+
+// NewHeightField returns a HeightField with width*depth zero heights.
+func NewHeightField(origin *T, cellSize float64, width, depth int) *HeightField {
+	return &HeightField{
+		Origin:   *origin,
+		CellSize: cellSize,
+		Width:    width,
+		Depth:    depth,
+		Heights:  make([]float64, width*depth),
+	}
+}
+
+// Sample returns the height of the sample at column x and row z including Origin[1].
+// Coordinates outside of the grid are clamped to the border.
+func (hf *HeightField) Sample(x, z int) float64 {
+	if x < 0 {
+		x = 0
+	} else if x >= hf.Width {
+		x = hf.Width - 1
+	}
+	if z < 0 {
+		z = 0
+	} else if z >= hf.Depth {
+		z = hf.Depth - 1
+	}
+	return hf.Origin[1] + hf.Heights[z*hf.Width+x]
+}
+
+// HeightAt returns the bilinearly interpolated height at the world coordinates x and z.
+// Outside of the grid the height of the nearest border is returned.
+func (hf *HeightField) HeightAt(x, z float64) float64 {
+	fx := (x - hf.Origin[0]) / hf.CellSize
+	fz := (z - hf.Origin[2]) / hf.CellSize
+	fx = math.Max(0, math.Min(fx, float64(hf.Width-1)))
+	fz = math.Max(0, math.Min(fz, float64(hf.Depth-1)))
+	ix, iz := math.Floor(fx), math.Floor(fz)
+	tx, tz := fx-ix, fz-iz
+	x0, z0 := int(ix), int(iz)
+	h00 := hf.Sample(x0, z0)
+	h10 := hf.Sample(x0+1, z0)
+	h01 := hf.Sample(x0, z0+1)
+	h11 := hf.Sample(x0+1, z0+1)
+	h0 := h00 + (h10-h00)*tx
+	h1 := h01 + (h11-h01)*tx
+	return h0 + (h1-h0)*tz
+}
+
+// NormalAt returns the unit surface normal at the world coordinates x and z
+// computed from central differences of HeightAt over one cell.
+// The differences read the heights one cell away, so within one cell of the border
+// they reach into the border clamping of HeightAt and the normal tilts towards up.
+func (hf *HeightField) NormalAt(x, z float64) T {
+	d := hf.CellSize
+	dx := (hf.HeightAt(x+d, z) - hf.HeightAt(x-d, z)) / (2 * d)
+	dz := (hf.HeightAt(x, z+d) - hf.HeightAt(x, z-d)) / (2 * d)
+	n := T{-dx, 1, -dz}
+	return *n.Normalize()
+}
@@ -0,0 +1,38 @@
+package vec3
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHeightField(t *testing.T) {
+	origin := T{-5, 1, 10}
+	hf := NewHeightField(&origin, 0.5, 20, 30)
+	// the tilted plane y = 1 + 0.2*x' - 0.1*z' relative to the origin
+	plane := func(x, z float64) float64 {
+		return 1 + 0.2*(x-origin[0]) - 0.1*(z-origin[2])
+	}
+	for z := 0; z < hf.Depth; z++ {
+		for x := 0; x < hf.Width; x++ {
+			hf.Heights[z*hf.Width+x] = plane(origin[0]+float64(x)*0.5, origin[2]+float64(z)*0.5) - origin[1]
+		}
+	}
+
+	for _, p := range [][2]float64{{-4.3, 11.1}, {0.01, 20.49}, {-2, 15.75}} {
+		if h, want := hf.HeightAt(p[0], p[1]), plane(p[0], p[1]); math.Abs(h-want) > EPSILON {
+			t.Errorf("height at %v is %v, want %v", p, h, want)
+		}
+	}
+	want := T{-0.2, 1, 0.1}
+	want.Normalize()
+	for _, p := range [][2]float64{{-3, 12}, {0, 18}} {
+		if n := hf.NormalAt(p[0], p[1]); Distance(&n, &want) > EPSILON {
+			t.Errorf("normal at %v is %v, want %v", p, n, want)
+		}
+	}
+
+	// heights are clamped at the border
+	if h, want := hf.HeightAt(-100, 10), plane(-5, 10); math.Abs(h-want) > EPSILON {
+		t.Errorf("height outside of the grid is %v, want %v", h, want)
+	}
+}
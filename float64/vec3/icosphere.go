@@ -0,0 +1,59 @@
+package vec3
+
+import (
+	"math"
+)
+
+// Icosphere returns a triangle mesh of the unit sphere created by subdividing
+// every triangle of an icosahedron subdivisions times into four triangles
+// and projecting the new vertices onto the sphere.
+// Vertices on shared edges are only created once.
+// The mesh has 20*4^subdivisions triangles which are wound
+// counter clockwise when looked at from outside of the sphere.
+func Icosphere(subdivisions int) (vertices []T, indices [][3]int) {
+	phi := (1 + math.Sqrt(5)) / 2
+	vertices = []T{
+		{-1, phi, 0}, {1, phi, 0}, {-1, -phi, 0}, {1, -phi, 0},
+		{0, -1, phi}, {0, 1, phi}, {0, -1, -phi}, {0, 1, -phi},
+		{phi, 0, -1}, {phi, 0, 1}, {-phi, 0, -1}, {-phi, 0, 1},
+	}
+	NormalizeSlice(vertices)
+	indices = [][3]int{
+		{0, 11, 5}, {0, 5, 1}, {0, 1, 7}, {0, 7, 10}, {0, 10, 11},
+		{1, 5, 9}, {5, 11, 4}, {11, 10, 2}, {10, 7, 6}, {7, 1, 8},
+		{3, 9, 4}, {3, 4, 2}, {3, 2, 6}, {3, 6, 8}, {3, 8, 9},
+		{4, 9, 5}, {2, 4, 11}, {6, 2, 10}, {8, 6, 7}, {9, 8, 1},
+	}
+
+	for s := 0; s < subdivisions; s++ {
+		midpoints := make(map[[2]int]int, len(indices)*3/2)
+		midpoint := func(a, b int) int {
+			key := [2]int{a, b}
+			if a > b {
+				key = [2]int{b, a}
+			}
+			if i, ok := midpoints[key]; ok {
+				return i
+			}
+			m := Add(&vertices[a], &vertices[b])
+			m.Normalize()
+			vertices = append(vertices, m)
+			midpoints[key] = len(vertices) - 1
+			return len(vertices) - 1
+		}
+		subdivided := make([][3]int, 0, len(indices)*4)
+		for _, tri := range indices {
+			ab := midpoint(tri[0], tri[1])
+			bc := midpoint(tri[1], tri[2])
+			ca := midpoint(tri[2], tri[0])
+			subdivided = append(subdivided,
+				[3]int{tri[0], ab, ca},
+				[3]int{tri[1], bc, ab},
+				[3]int{tri[2], ca, bc},
+				[3]int{ab, bc, ca},
+			)
+		}
+		indices = subdivided
+	}
+	return vertices, indices
+}
@@ -0,0 +1,33 @@
+package vec3
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIcosphere(t *testing.T) {
+	for subdivisions := 0; subdivisions <= 3; subdivisions++ {
+		vertices, indices := Icosphere(subdivisions)
+		faces := 20 << (2 * uint(subdivisions))
+		if len(indices) != faces {
+			t.Errorf("%d subdivisions have %d faces, want %d", subdivisions, len(indices), faces)
+		}
+		// Euler's formula V - E + F = 2 with E = 3F/2 holds only without duplicate vertices
+		if want := faces/2 + 2; len(vertices) != want {
+			t.Errorf("%d subdivisions have %d vertices, want %d", subdivisions, len(vertices), want)
+		}
+		for i := range vertices {
+			if math.Abs(vertices[i].Length()-1) > EPSILON {
+				t.Fatalf("vertex %v is not on the unit sphere", vertices[i])
+			}
+		}
+		for _, tri := range indices {
+			e1 := Sub(&vertices[tri[1]], &vertices[tri[0]])
+			e2 := Sub(&vertices[tri[2]], &vertices[tri[0]])
+			normal := Cross(&e1, &e2)
+			if Dot(&normal, &vertices[tri[0]]) <= 0 {
+				t.Fatalf("triangle %v is not wound counter clockwise from outside", tri)
+			}
+		}
+	}
+}
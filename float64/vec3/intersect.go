@@ -0,0 +1,362 @@
+package vec3
+
+import (
+	"math"
+)
+
+// IntersectRayPlane intersects the ray starting at origin with direction dir
+// with the plane through planePoint with the normal planeNormal.
+// It returns the intersection point and the ray parameter t
+// so that point = origin + dir*t.
+// hit is false if the ray is parallel to the plane
+// or if the intersection lies behind the origin.
+func IntersectRayPlane(origin, dir, planePoint, planeNormal *T) (point T, t float64, hit bool) {
+	denom := Dot(dir, planeNormal)
+	if math.Abs(denom) < 1e-12 {
+		return Zero, 0, false
+	}
+	toPlane := Sub(planePoint, origin)
+	t = Dot(&toPlane, planeNormal) / denom
+	if t < 0 {
+		return Zero, t, false
+	}
+	d := dir.Scaled(t)
+	return Add(origin, &d), t, true
+}
+
+// IntersectSegmentSphere intersects the line segment from a to b
+// with the sphere around center with the given radius.
+// It returns the parameter t in [0,1] of the first intersection
+// along the segment so that the point is a + (b-a)*t.
+// If a lies inside the sphere, t is 0 and hit is true.
+func IntersectSegmentSphere(a, b, center *T, radius float64) (t float64, hit bool) {
+	m := Sub(a, center)
+	c := m.LengthSqr() - radius*radius
+	if c <= 0 {
+		return 0, true
+	}
+	d := Sub(b, a)
+	dd := d.LengthSqr()
+	if dd == 0 {
+		return 0, false
+	}
+	md := Dot(&m, &d)
+	if md > 0 {
+		// a is outside and the segment points away from the sphere
+		return 0, false
+	}
+	discr := md*md - dd*c
+	if discr < 0 {
+		return 0, false
+	}
+	t = (-md - math.Sqrt(discr)) / dd
+	if t > 1 {
+		return 0, false
+	}
+	return t, true
+}
+
+// ClosestPointOnEllipsoid returns the point on the surface of the axis aligned
+// ellipsoid around center with the semi-axis lengths radii that is closest to p,
+// for p inside as well as outside of the ellipsoid.
+// It follows David Eberly, Distance from a Point to an Ellipse, an Ellipsoid, or a Hyperellipsoid:
+// p is reflected into the first octant and the axes are sorted by descending radius.
+// If no component of p is zero, the closest point is x_i = r_i²*y_i / (t + r_i²)
+// for the root t > -min(r_i²) of f(t) = sum((r_i*y_i / (t + r_i²))²) - 1, found by bisection.
+// Zero components lead to the lower dimensional ellipse problem or, for interior points
+// on the plane of the smallest axis, to a closest point off that plane.
+// For the center itself a point on the smallest semi-axis is returned.
+func ClosestPointOnEllipsoid(p, center, radii *T) T {
+	y := Sub(p, center)
+	// axes sorted by descending radius
+	order := [3]int{0, 1, 2}
+	for i := 0; i < 2; i++ {
+		for j := i + 1; j < 3; j++ {
+			if radii[order[j]] > radii[order[i]] {
+				order[i], order[j] = order[j], order[i]
+			}
+		}
+	}
+	var e, z [3]float64
+	for k, axis := range order {
+		e[k] = radii[axis]
+		z[k] = math.Abs(y[axis])
+	}
+
+	var x [3]float64
+	switch {
+	case z[2] > 0 && z[1] > 0 && z[0] > 0:
+		x = ellipsoidOctantPoint(e, z)
+	case z[2] > 0 && z[1] > 0:
+		x[1], x[2] = closestPointOnEllipse(e[1], e[2], z[1], z[2])
+	case z[2] > 0 && z[0] > 0:
+		x[0], x[2] = closestPointOnEllipse(e[0], e[2], z[0], z[2])
+	case z[2] > 0:
+		x[2] = e[2]
+	default:
+		// on the plane of the smallest axis the closest point may leave the plane
+		computed := false
+		denom0 := e[0]*e[0] - e[2]*e[2]
+		denom1 := e[1]*e[1] - e[2]*e[2]
+		ey0, ey1 := e[0]*z[0], e[1]*z[1]
+		if ey0 < denom0 && ey1 < denom1 {
+			xde0, xde1 := ey0/denom0, ey1/denom1
+			if discr := 1 - xde0*xde0 - xde1*xde1; discr > 0 {
+				x = [3]float64{e[0] * xde0, e[1] * xde1, e[2] * math.Sqrt(discr)}
+				computed = true
+			}
+		}
+		if !computed {
+			x[0], x[1] = closestPointOnEllipse(e[0], e[1], z[0], z[1])
+		}
+	}
+
+	result := *center
+	for k, axis := range order {
+		result[axis] += math.Copysign(x[k], y[axis])
+	}
+	return result
+}
+
+// closestPointOnEllipse returns the point of the ellipse with the semi-axis
+// lengths e0 >= e1 closest to the point y0, y1 in the first quadrant.
+func closestPointOnEllipse(e0, e1, y0, y1 float64) (x0, x1 float64) {
+	if y1 > 0 {
+		if y0 > 0 {
+			x := ellipsoidOctantPoint([3]float64{e0, e1}, [3]float64{y0, y1})
+			return x[0], x[1]
+		}
+		return 0, e1
+	}
+	denom := e0*e0 - e1*e1
+	if e0*y0 < denom {
+		xde0 := e0 * y0 / denom
+		return e0 * xde0, e1 * math.Sqrt(1-xde0*xde0)
+	}
+	return e0, 0
+}
+
+// ellipsoidOctantPoint returns the closest point for the positive components y
+// of the semi-axis lengths e sorted in descending order, where zero length axes
+// at the end are ignored, by bisecting the root of the Lagrange multiplier t.
+func ellipsoidOctantPoint(e, y [3]float64) [3]float64 {
+	n := 3
+	for n > 0 && e[n-1] == 0 {
+		n--
+	}
+	f := func(t float64) float64 {
+		sum := -1.0
+		for i := 0; i < n; i++ {
+			v := e[i] * y[i] / (t + e[i]*e[i])
+			sum += v * v
+		}
+		return sum
+	}
+	length := 0.0
+	for i := 0; i < n; i++ {
+		length += y[i] * y[i]
+	}
+	lower := -e[n-1] * e[n-1]
+	upper := e[0] * math.Sqrt(length)
+	for i := 0; i < 200; i++ {
+		mid := 0.5 * (lower + upper)
+		if mid == lower || mid == upper {
+			break
+		}
+		if f(mid) > 0 {
+			lower = mid
+		} else {
+			upper = mid
+		}
+	}
+	t := 0.5 * (lower + upper)
+	var x [3]float64
+	for i := 0; i < n; i++ {
+		e2 := e[i] * e[i]
+		x[i] = e2 * y[i] / (t + e2)
+	}
+	return x
+}
+
+// SupportPoint returns the point of the convex point set points that is
+// furthest in the given direction, which is the support function used by GJK.
+// The zero vector is returned for an empty point set.
+func SupportPoint(points []T, direction *T) T {
+	if len(points) == 0 {
+		return Zero
+	}
+	best := 0
+	bestDot := Dot(&points[0], direction)
+	for i := 1; i < len(points); i++ {
+		if d := Dot(&points[i], direction); d > bestDot {
+			best = i
+			bestDot = d
+		}
+	}
+	return points[best]
+}
+
+// ClosestApproachTime returns the time t at which two points moving
+// with the constant velocities velA and velB starting at posA and posB
+// are closest to each other. t is negative if the points are moving apart.
+// If the relative velocity is zero, the distance never changes and 0 is returned.
+func ClosestApproachTime(posA, velA, posB, velB *T) float64 {
+	dp := Sub(posB, posA)
+	dv := Sub(velB, velA)
+	dvLenSqr := dv.LengthSqr()
+	if dvLenSqr == 0 {
+		return 0
+	}
+	return -Dot(&dp, &dv) / dvLenSqr
+}
+
+// TriangleIntersectsAABB returns if the triangle a, b, c intersects
+// the axis aligned box from boxMin to boxMax, including touching.
+// It implements the separating axis test of Akenine-Möller with the
+// 13 candidate axes: the three box face normals, the triangle normal
+// and the nine cross products of the box and triangle edges.
+func TriangleIntersectsAABB(a, b, c, boxMin, boxMax *T) bool {
+	center := Interpolate(boxMin, boxMax, 0.5)
+	half := Sub(boxMax, &center)
+	// move the box to the origin
+	v := [3]T{Sub(a, &center), Sub(b, &center), Sub(c, &center)}
+	e := [3]T{Sub(&v[1], &v[0]), Sub(&v[2], &v[1]), Sub(&v[0], &v[2])}
+
+	separated := func(axis *T) bool {
+		p0 := Dot(&v[0], axis)
+		p1 := Dot(&v[1], axis)
+		p2 := Dot(&v[2], axis)
+		r := half[0]*math.Abs(axis[0]) + half[1]*math.Abs(axis[1]) + half[2]*math.Abs(axis[2])
+		return math.Min(p0, math.Min(p1, p2)) > r || math.Max(p0, math.Max(p1, p2)) < -r
+	}
+
+	// the nine edge cross product axes
+	boxAxes := [3]T{UnitX, UnitY, UnitZ}
+	for i := range boxAxes {
+		for j := range e {
+			axis := Cross(&boxAxes[i], &e[j])
+			if separated(&axis) {
+				return false
+			}
+		}
+	}
+	// the box face normals
+	for i := range boxAxes {
+		if separated(&boxAxes[i]) {
+			return false
+		}
+	}
+	// the triangle normal
+	normal := Cross(&e[0], &e[1])
+	return !separated(&normal)
+}
+
+// ConvexOverlap returns if the convex polyhedra with the vertices vertsA and vertsB overlap,
+// including touching, using the separating axis theorem.
+// axesA and axesB are the face normals of the polyhedra, for polyhedra
+// whose edge directions are not among the face normals, append the edge directions as well.
+// The candidate axes are all given axes and the cross products of every axis of A
+// with every axis of B. Both vertex sets are projected onto each candidate axis
+// and the polyhedra overlap if no axis separates the projections.
+func ConvexOverlap(vertsA, axesA, vertsB, axesB []T) bool {
+	separated := func(axis *T) bool {
+		minA, maxA := projectionInterval(vertsA, axis)
+		minB, maxB := projectionInterval(vertsB, axis)
+		return maxA < minB || maxB < minA
+	}
+	for i := range axesA {
+		if separated(&axesA[i]) {
+			return false
+		}
+	}
+	for i := range axesB {
+		if separated(&axesB[i]) {
+			return false
+		}
+	}
+	for i := range axesA {
+		for j := range axesB {
+			axis := Cross(&axesA[i], &axesB[j])
+			if axis.LengthSqr() < 1e-24 {
+				// parallel axes, already covered by the face normals
+				continue
+			}
+			if separated(&axis) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func projectionInterval(verts []T, axis *T) (min, max float64) {
+	min, max = math.Inf(1), math.Inf(-1)
+	for i := range verts {
+		d := Dot(&verts[i], axis)
+		min = math.Min(min, d)
+		max = math.Max(max, d)
+	}
+	return min, max
+}
+
+// ClosestPointsOnSegments returns the points c0 on the segment from a0 to a1
+// and c1 on the segment from b0 to b1 that are closest to each other.
+// For parallel segments one of the closest pairs is returned.
+// See Christer Ericson, Real-Time Collision Detection, 5.1.9
+func ClosestPointsOnSegments(a0, a1, b0, b1 *T) (c0, c1 T) {
+	d0 := Sub(a1, a0)
+	d1 := Sub(b1, b0)
+	r := Sub(a0, b0)
+	l0 := d0.LengthSqr()
+	l1 := d1.LengthSqr()
+	f := Dot(&d1, &r)
+	clamp01 := func(x float64) float64 {
+		return math.Max(0, math.Min(x, 1))
+	}
+	var s, t float64
+	switch {
+	case l0 == 0 && l1 == 0:
+		return *a0, *b0
+	case l0 == 0:
+		t = clamp01(f / l1)
+	default:
+		c := Dot(&d0, &r)
+		if l1 == 0 {
+			s = clamp01(-c / l0)
+		} else {
+			b := Dot(&d0, &d1)
+			if denom := l0*l1 - b*b; denom != 0 {
+				s = clamp01((b*f - c*l1) / denom)
+			}
+			t = (b*s + f) / l1
+			if t < 0 {
+				t = 0
+				s = clamp01(-c / l0)
+			} else if t > 1 {
+				t = 1
+				s = clamp01((b - c) / l0)
+			}
+		}
+	}
+	c0 = d0.Scaled(s)
+	c0.Add(a0)
+	c1 = d1.Scaled(t)
+	c1.Add(b0)
+	return c0, c1
+}
+
+// DistanceToCapsule returns the signed distance from p to the surface of the capsule
+// around the segment from a to b with the given radius.
+// The distance is negative for points inside the capsule.
+func DistanceToCapsule(p, a, b *T, radius float64) float64 {
+	c := closestPointOnSegment(p, a, b)
+	return Distance(p, &c) - radius
+}
+
+// CapsulesOverlap returns if the capsule around the segment from a0 to a1 with radius r0
+// and the capsule around the segment from b0 to b1 with radius r1 overlap or touch.
+func CapsulesOverlap(a0, a1 *T, r0 float64, b0, b1 *T, r1 float64) bool {
+	c0, c1 := ClosestPointsOnSegments(a0, a1, b0, b1)
+	r := r0 + r1
+	return SquareDistance(&c0, &c1) <= r*r
+}
@@ -0,0 +1,264 @@
+package vec3
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIntersectRayPlane(t *testing.T) {
+	origin := T{1, 5, -2}
+	down := T{0, -1, 0}
+	point, param, hit := IntersectRayPlane(&origin, &down, &Zero, &UnitY)
+	if !hit {
+		t.Fatal("downward ray missed the ground plane")
+	}
+	if want := (T{1, 0, -2}); point != want || param != 5 {
+		t.Errorf("intersection is %v at t=%v, want %v at t=5", point, param, want)
+	}
+
+	if _, _, hit := IntersectRayPlane(&origin, &UnitX, &Zero, &UnitY); hit {
+		t.Error("ray parallel to the plane must not hit")
+	}
+	if _, _, hit := IntersectRayPlane(&origin, &UnitY, &Zero, &UnitY); hit {
+		t.Error("ray pointing away from the plane must not hit")
+	}
+
+	slanted := T{1, -1, 0}
+	point, param, hit = IntersectRayPlane(&origin, &slanted, &Zero, &UnitY)
+	if want := (T{6, 0, -2}); !hit || Distance(&point, &want) > EPSILON || math.Abs(param-5) > EPSILON {
+		t.Errorf("slanted intersection is %v at t=%v (hit=%v), want %v", point, param, hit, want)
+	}
+}
+
+func TestClosestPointOnEllipsoid(t *testing.T) {
+	center := T{1, 2, 3}
+	radii := T{3, 2, 1}
+
+	p := T{10, 2, 3}
+	if got, want := ClosestPointOnEllipsoid(&p, &center, &radii), (T{4, 2, 3}); Distance(&got, &want) > EPSILON {
+		t.Errorf("closest point along the X axis is %v, want %v", got, want)
+	}
+	p = T{1, 2, 3.5}
+	if got, want := ClosestPointOnEllipsoid(&p, &center, &radii), (T{1, 2, 4}); Distance(&got, &want) > EPSILON {
+		t.Errorf("closest point along the Z axis from inside is %v, want %v", got, want)
+	}
+
+	// inside on the longest axis the closest point is off the axis towards the shortest one
+	p = T{2, 2, 3}
+	got := ClosestPointOnEllipsoid(&p, &center, &radii)
+	if d := Distance(&got, &p); math.Abs(d-math.Sqrt(7.0/8)) > 1e-9 {
+		t.Errorf("closest point %v for the interior point %v is at distance %v, want %v", got, p, d, math.Sqrt(7.0/8))
+	}
+	if want := (T{1 + 9.0/8, 2, 3 + math.Sqrt(55.0/64)}); Distance(&got, &want) > 1e-9 {
+		t.Errorf("closest point for the interior point %v is %v, want %v", p, got, want)
+	}
+	if got := ClosestPointOnEllipsoid(&center, &center, &radii); Distance(&got, &T{1, 2, 4}) > EPSILON {
+		t.Errorf("closest point for the center is %v, want %v", got, T{1, 2, 4})
+	}
+
+	// points inside and outside with zero components are checked against sampling
+	for _, p := range []T{{5, 4, 5}, {1.5, 2.5, 3.2}, {-3, 0, 1}, {1, 2, 3}, {1, 2.5, 3}, {0, 3, 3}, {2.5, 2, 3.1}, {1, 4, 3.5}} {
+		got := ClosestPointOnEllipsoid(&p, &center, &radii)
+		local := Sub(&got, &center)
+		if e := local[0]*local[0]/9 + local[1]*local[1]/4 + local[2]*local[2]; math.Abs(e-1) > 0.0001 {
+			t.Errorf("closest point %v for %v is not on the surface", got, p)
+		}
+		// no sampled surface point may be closer
+		dist := Distance(&got, &p)
+		for i := 0; i < 40000; i++ {
+			theta := math.Pi * float64(i/200) / 199
+			phi := 2 * math.Pi * float64(i%200) / 200
+			s := T{
+				center[0] + radii[0]*math.Sin(theta)*math.Cos(phi),
+				center[1] + radii[1]*math.Sin(theta)*math.Sin(phi),
+				center[2] + radii[2]*math.Cos(theta),
+			}
+			if d := Distance(&s, &p); d < dist-1e-9 {
+				t.Errorf("surface point %v is closer to %v than %v", s, p, got)
+				break
+			}
+		}
+	}
+}
+
+func TestSupportPoint(t *testing.T) {
+	var cube []T
+	for i := 0; i < 8; i++ {
+		cube = append(cube, T{float64(i & 1), float64((i >> 1) & 1), float64((i >> 2) & 1)})
+	}
+	tests := []struct{ dir, want T }{
+		{T{1, 1, 1}, T{1, 1, 1}},
+		{T{-1, -1, -1}, T{0, 0, 0}},
+		{T{1, -2, 0.5}, T{1, 0, 1}},
+		{T{-0.1, 3, -1}, T{0, 1, 0}},
+	}
+	for _, test := range tests {
+		if got := SupportPoint(cube, &test.dir); got != test.want {
+			t.Errorf("support in direction %v is %v, want %v", test.dir, got, test.want)
+		}
+	}
+}
+
+func TestIntersectSegmentSphere(t *testing.T) {
+	center := T{0, 0, 0}
+
+	a, b := T{-5, 0, 0}, T{5, 0, 0}
+	if param, hit := IntersectSegmentSphere(&a, &b, &center, 2); !hit || math.Abs(param-0.3) > EPSILON {
+		t.Errorf("segment through the sphere hits at t=%v (hit=%v), want t=0.3", param, hit)
+	}
+
+	a, b = T{-5, 3, 0}, T{5, 3, 0}
+	if _, hit := IntersectSegmentSphere(&a, &b, &center, 2); hit {
+		t.Error("segment passing above the sphere must not hit")
+	}
+
+	a, b = T{-5, 0, 0}, T{-3, 0, 0}
+	if _, hit := IntersectSegmentSphere(&a, &b, &center, 2); hit {
+		t.Error("segment ending before the sphere must not hit")
+	}
+
+	a, b = T{0.5, 0, 0}, T{5, 0, 0}
+	if param, hit := IntersectSegmentSphere(&a, &b, &center, 2); !hit || param != 0 {
+		t.Errorf("segment starting inside hits at t=%v (hit=%v), want t=0", param, hit)
+	}
+}
+
+func TestClosestApproachTime(t *testing.T) {
+	posA, velA := T{-10, 0, 0}, T{2, 0, 0}
+	posB, velB := T{10, 1, 0}, T{-2, 0, 0}
+	if got := ClosestApproachTime(&posA, &velA, &posB, &velB); math.Abs(got-5) > EPSILON {
+		t.Errorf("converging points are closest at t=%v, want 5", got)
+	}
+	if got := ClosestApproachTime(&posB, &velB, &posA, &velA); math.Abs(got-5) > EPSILON {
+		t.Errorf("swapped converging points are closest at t=%v, want 5", got)
+	}
+
+	// moving apart, the closest approach was in the past
+	velA.Invert()
+	velB.Invert()
+	if got := ClosestApproachTime(&posA, &velA, &posB, &velB); math.Abs(got+5) > EPSILON {
+		t.Errorf("diverging points were closest at t=%v, want -5", got)
+	}
+
+	parallel := T{1, 2, 3}
+	if got := ClosestApproachTime(&posA, &parallel, &posB, &parallel); got != 0 {
+		t.Errorf("points moving in parallel give t=%v, want 0", got)
+	}
+}
+
+func TestTriangleIntersectsAABB(t *testing.T) {
+	boxMin, boxMax := T{0, 0, 0}, T{2, 2, 2}
+
+	a, b, c := T{0.5, 0.5, 1}, T{1.5, 0.5, 1}, T{1, 1.5, 1}
+	if !TriangleIntersectsAABB(&a, &b, &c, &boxMin, &boxMax) {
+		t.Error("triangle inside the box must intersect")
+	}
+
+	a, b, c = T{3, 3, 3}, T{4, 3, 3}, T{3, 4, 3}
+	if TriangleIntersectsAABB(&a, &b, &c, &boxMin, &boxMax) {
+		t.Error("triangle far outside the box must not intersect")
+	}
+
+	// large triangle cutting through the box with all vertices outside
+	a, b, c = T{-10, -10, 1}, T{10, -10, 1}, T{0, 10, 1}
+	if !TriangleIntersectsAABB(&a, &b, &c, &boxMin, &boxMax) {
+		t.Error("large triangle through the box must intersect")
+	}
+
+	// triangle whose edge touches the top edge of the box
+	a, b, c = T{-1, 2, 3}, T{3, 2, 3}, T{1, 2, 2}
+	if !TriangleIntersectsAABB(&a, &b, &c, &boxMin, &boxMax) {
+		t.Error("triangle grazing the box edge must intersect")
+	}
+
+	// overlaps the box on every face axis, but passes beside the vertical box edge
+	a, b, c = T{1.5, 3, 0}, T{3, 1.5, 0}, T{3, 3, 2}
+	if TriangleIntersectsAABB(&a, &b, &c, &boxMin, &boxMax) {
+		t.Error("triangle beside the box edge must not intersect")
+	}
+}
+
+func TestConvexOverlap(t *testing.T) {
+	boxVerts := func(center T, halfSize float64, rotate bool) []T {
+		verts := make([]T, 0, 8)
+		for i := 0; i < 8; i++ {
+			v := T{-halfSize, -halfSize, -halfSize}
+			if i&1 != 0 {
+				v[0] = halfSize
+			}
+			if i&2 != 0 {
+				v[1] = halfSize
+			}
+			if i&4 != 0 {
+				v[2] = halfSize
+			}
+			if rotate {
+				// 45 degrees around the Z axis
+				v = T{(v[0] - v[1]) / math.Sqrt2, (v[0] + v[1]) / math.Sqrt2, v[2]}
+			}
+			verts = append(verts, Add(&v, &center))
+		}
+		return verts
+	}
+	axes := []T{UnitX, UnitY, UnitZ}
+	rotatedAxes := []T{{1 / math.Sqrt2, 1 / math.Sqrt2, 0}, {-1 / math.Sqrt2, 1 / math.Sqrt2, 0}, UnitZ}
+
+	a := boxVerts(Zero, 1, false)
+	b := boxVerts(T{1.5, 0.5, 0}, 1, false)
+	if !ConvexOverlap(a, axes, b, axes) {
+		t.Error("overlapping boxes must overlap")
+	}
+	c := boxVerts(T{3, 0, 0}, 1, false)
+	if ConvexOverlap(a, axes, c, axes) {
+		t.Error("separated boxes must not overlap")
+	}
+
+	// the rotated box reaches sqrt(2) along X
+	d := boxVerts(T{2.3, 0, 0}, 1, true)
+	if !ConvexOverlap(a, axes, d, rotatedAxes) {
+		t.Error("rotated box with its edge inside must overlap")
+	}
+	e := boxVerts(T{2.5, 0, 0}, 1, true)
+	if ConvexOverlap(a, axes, e, rotatedAxes) {
+		t.Error("rotated box beyond its edge distance must not overlap")
+	}
+}
+
+func TestClosestPointsOnSegments(t *testing.T) {
+	c0, c1 := ClosestPointsOnSegments(&T{-1, 0, 0}, &T{1, 0, 0}, &T{0.5, -1, 2}, &T{0.5, 1, 2})
+	if Distance(&c0, &T{0.5, 0, 0}) > EPSILON || Distance(&c1, &T{0.5, 0, 2}) > EPSILON {
+		t.Errorf("closest points of crossing segments are %v, %v", c0, c1)
+	}
+	// the closest point of the second segment is its end point
+	c0, c1 = ClosestPointsOnSegments(&T{0, 0, 0}, &T{4, 0, 0}, &T{6, 1, 0}, &T{8, 3, 0})
+	if Distance(&c0, &T{4, 0, 0}) > EPSILON || Distance(&c1, &T{6, 1, 0}) > EPSILON {
+		t.Errorf("closest points of separated segments are %v, %v", c0, c1)
+	}
+	c0, c1 = ClosestPointsOnSegments(&T{0, 0, 0}, &T{2, 0, 0}, &T{1, 1, 0}, &T{3, 1, 0})
+	if d := Distance(&c0, &c1); math.Abs(d-1) > EPSILON {
+		t.Errorf("distance of parallel segments is %v, want 1", d)
+	}
+}
+
+func TestCapsules(t *testing.T) {
+	a, b := T{0, 0, 0}, T{0, 2, 0}
+	if d := DistanceToCapsule(&T{0.5, 1, 0}, &a, &b, 1); math.Abs(d+0.5) > EPSILON {
+		t.Errorf("distance of the inside point is %v, want -0.5", d)
+	}
+	if d := DistanceToCapsule(&T{0, 4, 0}, &a, &b, 1); math.Abs(d-1) > EPSILON {
+		t.Errorf("distance of the point above the cap is %v, want 1", d)
+	}
+	if d := DistanceToCapsule(&T{3, 1, 4}, &a, &b, 1); math.Abs(d-4) > EPSILON {
+		t.Errorf("distance of the outside point is %v, want 4", d)
+	}
+
+	if !CapsulesOverlap(&a, &b, 0.5, &T{0.8, 1, -1}, &T{0.8, 1, 1}, 0.5) {
+		t.Errorf("crossing capsules don't overlap")
+	}
+	if CapsulesOverlap(&a, &b, 0.5, &T{1.2, 1, -1}, &T{1.2, 1, 1}, 0.5) {
+		t.Errorf("separated capsules overlap")
+	}
+	if !CapsulesOverlap(&a, &b, 0.5, &T{0, 3, 0}, &T{0, 5, 0}, 0.6) {
+		t.Errorf("capsules overlapping at their caps don't overlap")
+	}
+}
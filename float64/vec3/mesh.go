@@ -0,0 +1,123 @@
+package vec3
+
+// LaplacianSmooth returns a copy of positions where every vertex is moved
+// towards the average of its neighbors by the factor lambda (0,1):
+// p' = p + lambda * (average(neighbors) - p).
+// neighbors[i] holds the indices of the vertices adjacent to vertex i,
+// vertices without neighbors are not moved. positions is not modified.
+func LaplacianSmooth(positions []T, neighbors [][]int, lambda float64) []T {
+	result := make([]T, len(positions))
+	copy(result, positions)
+	for i := range positions {
+		if i >= len(neighbors) || len(neighbors[i]) == 0 {
+			continue
+		}
+		var avg T
+		for _, n := range neighbors[i] {
+			avg.Add(&positions[n])
+		}
+		avg.Scale(1 / float64(len(neighbors[i])))
+		d := Sub(&avg, &positions[i])
+		d.Scale(lambda)
+		result[i].Add(&d)
+	}
+	return result
+}
+
+// MeanCurvature returns the discrete mean curvature at the vertex center
+// from the cotangent weighted Laplacian over its one-ring neighbors.
+// The neighbors must be ordered around center (clockwise or counter-clockwise)
+// forming a closed ring, so that center, neighbors[i] and neighbors[i+1]
+// as well as center, the last and the first neighbor are the adjacent triangles.
+// The result is the magnitude of the mean curvature normal divided by 2,
+// normalized by the mixed Voronoi area of Meyer et al. around center.
+// It is about 1/r for a vertex of a mesh approximating a sphere with radius r.
+// Less than 3 neighbors or a ring with zero area return 0.
+func MeanCurvature(center *T, neighbors []T) float64 {
+	n := len(neighbors)
+	if n < 3 {
+		return 0
+	}
+	var laplacian T
+	area := 0.0
+	for i := range neighbors {
+		prev := &neighbors[(i+n-1)%n]
+		cur := &neighbors[i]
+		next := &neighbors[(i+1)%n]
+		// cotangents of the angles opposite to the edge from center to cur
+		w := cotangent(prev, center, cur) + cotangent(next, center, cur)
+		d := Sub(cur, center)
+		d.Scale(w)
+		laplacian.Add(&d)
+
+		area += mixedArea(center, cur, next)
+	}
+	if area == 0 {
+		return 0
+	}
+	laplacian.Scale(1 / (2 * area))
+	return laplacian.Length() * 0.5
+}
+
+// cotangent returns the cotangent of the angle at the vertex apex
+// of the triangle apex, a, b.
+func cotangent(apex, a, b *T) float64 {
+	u := Sub(a, apex)
+	v := Sub(b, apex)
+	cross := Cross(&u, &v)
+	l := cross.Length()
+	if l == 0 {
+		return 0
+	}
+	return Dot(&u, &v) / l
+}
+
+// mixedArea returns the part of the area of the triangle p, a, b that belongs to p:
+// the Voronoi region of p for non-obtuse triangles, half the triangle area
+// if the angle at p is obtuse and a quarter of it if another angle is obtuse.
+func mixedArea(p, a, b *T) float64 {
+	pa := Sub(a, p)
+	pb := Sub(b, p)
+	cross := Cross(&pa, &pb)
+	area := cross.Length() * 0.5
+	if area == 0 {
+		return 0
+	}
+	ab := Sub(b, a)
+	switch {
+	case Dot(&pa, &pb) < 0:
+		return area * 0.5
+	case Dot(&pa, &ab) > 0 || Dot(&pb, &ab) < 0:
+		// obtuse angle at a or b
+		return area * 0.25
+	}
+	return (pa.LengthSqr()*cotangent(b, p, a) + pb.LengthSqr()*cotangent(a, p, b)) / 8
+}
+
+// SmoothNormals computes smooth normals that are not blended across sharp edges.
+// vertexFaces[v] holds the indices into faceNormals of the faces adjacent to vertex v.
+// For every vertex and every adjacent face a corner normal is computed by averaging
+// the unit normals of all faces adjacent to the vertex whose angle to that face
+// is at most angleThreshold (in radians), so faces meeting at sharper angles keep hard edges.
+// The result holds one unit normal per entry of vertexFaces, flattened in its order:
+// first the corner normals of vertex 0 for vertexFaces[0] in order, then those of vertex 1 and so on.
+func SmoothNormals(faceNormals []T, vertexFaces [][]int, angleThreshold float64) []T {
+	count := 0
+	for _, faces := range vertexFaces {
+		count += len(faces)
+	}
+	result := make([]T, 0, count)
+	for _, faces := range vertexFaces {
+		for _, f := range faces {
+			var sum T
+			for _, other := range faces {
+				if AngleBetween(&faceNormals[f], &faceNormals[other]) <= angleThreshold {
+					n := faceNormals[other].Normalized()
+					sum.Add(&n)
+				}
+			}
+			result = append(result, sum.Normalized())
+		}
+	}
+	return result
+}
@@ -0,0 +1,121 @@
+package vec3
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestLaplacianSmooth(t *testing.T) {
+	const size = 10
+	rng := rand.New(rand.NewSource(9))
+	positions := make([]T, 0, size*size)
+	neighbors := make([][]int, 0, size*size)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			positions = append(positions, T{float64(x), float64(y), rng.Float64()*0.4 - 0.2})
+			var n []int
+			if x > 0 {
+				n = append(n, y*size+x-1)
+			}
+			if x < size-1 {
+				n = append(n, y*size+x+1)
+			}
+			if y > 0 {
+				n = append(n, (y-1)*size+x)
+			}
+			if y < size-1 {
+				n = append(n, (y+1)*size+x)
+			}
+			neighbors = append(neighbors, n)
+		}
+	}
+	original := append([]T(nil), positions...)
+	smoothed := LaplacianSmooth(positions, neighbors, 0.5)
+
+	variance := func(points []T) float64 {
+		mean := 0.0
+		for i := range points {
+			mean += points[i][2]
+		}
+		mean /= float64(len(points))
+		v := 0.0
+		for i := range points {
+			v += (points[i][2] - mean) * (points[i][2] - mean)
+		}
+		return v / float64(len(points))
+	}
+	if before, after := variance(positions), variance(smoothed); after >= before*0.7 {
+		t.Errorf("variance of the heights only changed from %v to %v", before, after)
+	}
+	for i := range positions {
+		if positions[i] != original[i] {
+			t.Fatal("input positions were modified")
+		}
+		// the grid keeps its shape, vertices move by less than half a cell
+		if d := Distance(&positions[i], &smoothed[i]); d > 0.5 {
+			t.Errorf("vertex %d moved by %v", i, d)
+		}
+	}
+	if center := smoothed[5*size+5]; math.Abs(center[0]-5) > 0.01 || math.Abs(center[1]-5) > 0.01 {
+		t.Errorf("inner vertex moved within the plane to %v", center)
+	}
+}
+
+func TestMeanCurvature(t *testing.T) {
+	const radius = 2.0
+	// the north pole of a sphere and a ring of neighbors at a polar angle of 10 degrees
+	center := T{0, radius, 0}
+	polar := 10 * math.Pi / 180
+	var ring []T
+	for i := 0; i < 8; i++ {
+		azimuth := float64(i) * 2 * math.Pi / 8
+		ring = append(ring, T{
+			radius * math.Sin(polar) * math.Cos(azimuth),
+			radius * math.Cos(polar),
+			radius * math.Sin(polar) * math.Sin(azimuth),
+		})
+	}
+	if h := MeanCurvature(&center, ring); math.Abs(h-1/radius) > 0.05/radius {
+		t.Errorf("mean curvature on the sphere is %v, want about %v", h, 1/radius)
+	}
+
+	flat := []T{{1, 0, 0}, {0, 0, 1}, {-1, 0, 0}, {0, 0, -1}}
+	if h := MeanCurvature(&Zero, flat); math.Abs(h) > EPSILON {
+		t.Errorf("mean curvature of a flat ring is %v, want 0", h)
+	}
+}
+
+func TestSmoothNormals(t *testing.T) {
+	// the faces of a cube and the three faces at each of its corners
+	faces := []T{{1, 0, 0}, {-1, 0, 0}, {0, 1, 0}, {0, -1, 0}, {0, 0, 1}, {0, 0, -1}}
+	var vertexFaces [][]int
+	for _, x := range []int{0, 1} {
+		for _, y := range []int{2, 3} {
+			for _, z := range []int{4, 5} {
+				vertexFaces = append(vertexFaces, []int{x, y, z})
+			}
+		}
+	}
+	normals := SmoothNormals(faces, vertexFaces, math.Pi/6)
+	if len(normals) != 24 {
+		t.Fatalf("got %d corner normals, want 24", len(normals))
+	}
+	i := 0
+	for _, vf := range vertexFaces {
+		for _, f := range vf {
+			if Distance(&normals[i], &faces[f]) > EPSILON {
+				t.Errorf("corner normal %v of face %v is blended across a 90 degree edge", normals[i], faces[f])
+			}
+			i++
+		}
+	}
+
+	// with a threshold above 90 degrees the corners are smoothed
+	normals = SmoothNormals(faces, vertexFaces[:1], math.Pi*0.6)
+	want := T{1, 1, 1}
+	want.Normalize()
+	if Distance(&normals[0], &want) > EPSILON {
+		t.Errorf("smoothed corner normal is %v, want %v", normals[0], want)
+	}
+}
@@ -0,0 +1,34 @@
+package vec3
+
+import (
+	"math"
+)
+
+// PackSnorm101010 packs the vector into the signed normalized 10-10-10 format.
+// Every component is clamped to [-1,1] and mapped to a 10 bit two's complement
+// integer in the range [-511,511], which gives a precision of 1/511.
+// X is stored in the bits 0-9, Y in the bits 10-19 and Z in the bits 20-29,
+// the two highest bits are zero.
+// See UnpackSnorm101010
+func (vec *T) PackSnorm101010() uint32 {
+	var packed uint32
+	for i := 0; i < 3; i++ {
+		v := math.Max(-1, math.Min(1, vec[i]))
+		q := int32(math.Round(v * 511))
+		packed |= (uint32(q) & 0x3ff) << (10 * uint(i))
+	}
+	return packed
+}
+
+// UnpackSnorm101010 unpacks a vector packed with T.PackSnorm101010.
+// The value -512, which PackSnorm101010 never produces, is clamped to -1.
+func UnpackSnorm101010(packed uint32) T {
+	var vec T
+	for i := 0; i < 3; i++ {
+		bits := (packed >> (10 * uint(i))) & 0x3ff
+		// sign extend the 10 bit value
+		q := int32(bits<<22) >> 22
+		vec[i] = math.Max(-1, float64(q)/511)
+	}
+	return vec
+}
@@ -0,0 +1,30 @@
+package vec3
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPackSnorm101010(t *testing.T) {
+	for _, v := range []T{{0, 0, 0}, {1, -1, 0.5}, {-0.25, 0.75, -0.999}, {0.123, -0.456, 0.789}} {
+		packed := v.PackSnorm101010()
+		if packed>>30 != 0 {
+			t.Errorf("packed %v uses the two highest bits: %x", v, packed)
+		}
+		got := UnpackSnorm101010(packed)
+		for i := range v {
+			if math.Abs(got[i]-v[i]) > 0.5/511+EPSILON {
+				t.Errorf("round trip of %v gives %v", v, got)
+				break
+			}
+		}
+	}
+
+	outOfRange := T{3, -7, 1.5}
+	if got, want := UnpackSnorm101010(outOfRange.PackSnorm101010()), (T{1, -1, 1}); got != want {
+		t.Errorf("out of range input unpacks to %v, want %v", got, want)
+	}
+	if got := UnpackSnorm101010(0x200); got[0] != -1 {
+		t.Errorf("-512 unpacks to %v, want -1", got[0])
+	}
+}
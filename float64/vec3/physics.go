@@ -0,0 +1,37 @@
+package vec3
+
+import "errors"
+
+// VerletStep advances the positions in place by one position Verlet integration step
+// of the time step dt with x' = 2x - xPrev + a*dt² and stores the previous
+// positions in prevPositions.
+// An error is returned if the slices have different lengths.
+func VerletStep(positions, prevPositions []T, accelerations []T, dt float64) error {
+	if len(prevPositions) != len(positions) || len(accelerations) != len(positions) {
+		return errors.New("vec3.VerletStep: slice lengths differ")
+	}
+	dt2 := dt * dt
+	for i := range positions {
+		x := positions[i]
+		for k := range x {
+			positions[i][k] = 2*x[k] - prevPositions[i][k] + accelerations[i][k]*dt2
+		}
+		prevPositions[i] = x
+	}
+	return nil
+}
+
+// SatisfyDistanceConstraint moves a and b in place along the line between them
+// towards the distance restLength, each by half of the correction scaled by
+// stiffness from 0 (no correction) to 1 (rest length reached in one step).
+// Coincident points are left unchanged because the direction is undefined.
+func SatisfyDistanceConstraint(a, b *T, restLength, stiffness float64) {
+	delta := Sub(b, a)
+	length := delta.Length()
+	if length == 0 {
+		return
+	}
+	delta.Scale(0.5 * stiffness * (length - restLength) / length)
+	a.Add(&delta)
+	b.Sub(&delta)
+}
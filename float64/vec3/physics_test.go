@@ -0,0 +1,80 @@
+package vec3
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVerletStep(t *testing.T) {
+	const dt = 0.01
+	gravity := T{0, -9.81, 0}
+	velocity := T{1, 2, 0}
+	start := T{0, 10, 0}
+	at := func(time float64) T {
+		return T{
+			start[0] + velocity[0]*time + 0.5*gravity[0]*time*time,
+			start[1] + velocity[1]*time + 0.5*gravity[1]*time*time,
+			start[2] + velocity[2]*time + 0.5*gravity[2]*time*time,
+		}
+	}
+	// starting from two exact positions Verlet is exact under constant acceleration
+	prev := []T{at(0)}
+	positions := []T{at(dt)}
+	accelerations := []T{gravity}
+	const steps = 100
+	for i := 0; i < steps; i++ {
+		if err := VerletStep(positions, prev, accelerations, dt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := at((steps + 1) * dt)
+	if d := Distance(&positions[0], &want); d > 1e-9 {
+		t.Errorf("position after %d steps is %v, want %v", steps, positions[0], want)
+	}
+	if wantPrev := at(steps * dt); Distance(&prev[0], &wantPrev) > 1e-9 {
+		t.Errorf("previous position is %v, want %v", prev[0], wantPrev)
+	}
+
+	if err := VerletStep(positions, prev, nil, dt); err == nil {
+		t.Error("no error for mismatched slice lengths")
+	}
+}
+
+func TestSatisfyDistanceConstraint(t *testing.T) {
+	a := T{0, 0, 0}
+	b := T{4, 3, 0}
+	center := Interpolate(&a, &b, 0.5)
+
+	SatisfyDistanceConstraint(&a, &b, 2, 1)
+	if d := Distance(&a, &b); math.Abs(d-2) > 1e-12 {
+		t.Errorf("full stiffness distance is %v, want 2", d)
+	}
+
+	a, b = T{0, 0, 0}, T{4, 3, 0}
+	SatisfyDistanceConstraint(&a, &b, 2, 0.5)
+	if d := Distance(&a, &b); math.Abs(d-3.5) > 1e-12 {
+		t.Errorf("half stiffness distance after one step is %v, want 3.5", d)
+	}
+	for i := 0; i < 50; i++ {
+		SatisfyDistanceConstraint(&a, &b, 2, 0.5)
+	}
+	if d := Distance(&a, &b); math.Abs(d-2) > 1e-9 {
+		t.Errorf("distance after relaxing is %v, want 2", d)
+	}
+	if c := Interpolate(&a, &b, 0.5); Distance(&c, &center) > 1e-12 {
+		t.Errorf("center moved from %v to %v", center, c)
+	}
+	dir := Sub(&b, &a)
+	if dir.Normalize(); Distance(&dir, &T{0.8, 0.6, 0}) > 1e-12 {
+		t.Errorf("direction changed to %v", dir)
+	}
+
+	// relaxing stretches compressed points as well
+	a, b = T{0, 0, 0}, T{0, 0.5, 0}
+	for i := 0; i < 50; i++ {
+		SatisfyDistanceConstraint(&a, &b, 2, 0.5)
+	}
+	if d := Distance(&a, &b); math.Abs(d-2) > 1e-9 {
+		t.Errorf("distance after stretching is %v, want 2", d)
+	}
+}
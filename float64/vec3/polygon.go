@@ -0,0 +1,175 @@
+package vec3
+
+import (
+	"errors"
+	"math"
+
+	"github.com/ungerik/go3d/float64/vec2"
+)
+
+// PolygonNormal returns the unit normal of the polygon with the vertices points
+// computed with Newell's method, which is robust for nearly planar polygons
+// and collinear vertices. The normal follows the counter-clockwise winding of the points.
+// The zero vector is returned for degenerate polygons.
+func PolygonNormal(points []T) T {
+	var normal T
+	for i := range points {
+		a := &points[i]
+		b := &points[(i+1)%len(points)]
+		normal[0] += (a[1] - b[1]) * (a[2] + b[2])
+		normal[1] += (a[2] - b[2]) * (a[0] + b[0])
+		normal[2] += (a[0] - b[0]) * (a[1] + b[1])
+	}
+	return *normal.Normalize()
+}
+
+// IsConvex returns if the planar polygon with the vertices points is convex.
+// All turns between consecutive edges must have the same orientation
+// relative to the polygon normal, collinear vertices and duplicate points are ignored.
+// The total turning angle must be one full turn to reject self-intersecting star shapes.
+// Polygons with less than 3 vertices or without area are not convex.
+func IsConvex(points []T) bool {
+	n := len(points)
+	if n < 3 {
+		return false
+	}
+	normal := PolygonNormal(points)
+	if normal.IsZero() {
+		return false
+	}
+	var totalAngle float64
+	for i := range points {
+		prev := &points[(i+n-1)%n]
+		curr := &points[i]
+		next := &points[(i+1)%n]
+		e1 := Sub(curr, prev)
+		e2 := Sub(next, curr)
+		if e1.IsZero() || e2.IsZero() {
+			continue
+		}
+		cross := Cross(&e1, &e2)
+		turn := Dot(&cross, &normal)
+		scale := e1.Length() * e2.Length()
+		if turn < -1e-12*scale {
+			return false
+		}
+		totalAngle += math.Atan2(turn, Dot(&e1, &e2))
+	}
+	return math.Abs(totalAngle-2*math.Pi) < 1e-6
+}
+
+// projectPolygon projects points onto the plane perpendicular to normal,
+// so that a polygon winding counter-clockwise around normal is counter-clockwise in 2D.
+func projectPolygon(points []T, normal *T) []vec2.T {
+	u := normal.Normal()
+	v := Cross(normal, &u)
+	projected := make([]vec2.T, len(points))
+	for i := range points {
+		projected[i] = vec2.T{Dot(&points[i], &u), Dot(&points[i], &v)}
+	}
+	return projected
+}
+
+// orient2D returns twice the signed area of the 2D triangle a, b, c,
+// positive for counter-clockwise winding.
+func orient2D(a, b, c *vec2.T) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
+
+// segmentsIntersect2D returns if the closed segments a-b and c-d intersect.
+func segmentsIntersect2D(a, b, c, d *vec2.T) bool {
+	d1 := orient2D(c, d, a)
+	d2 := orient2D(c, d, b)
+	d3 := orient2D(a, b, c)
+	d4 := orient2D(a, b, d)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	onSegment := func(p, q, r *vec2.T) bool {
+		return math.Min(p[0], q[0]) <= r[0] && r[0] <= math.Max(p[0], q[0]) &&
+			math.Min(p[1], q[1]) <= r[1] && r[1] <= math.Max(p[1], q[1])
+	}
+	return (d1 == 0 && onSegment(c, d, a)) || (d2 == 0 && onSegment(c, d, b)) ||
+		(d3 == 0 && onSegment(a, b, c)) || (d4 == 0 && onSegment(a, b, d))
+}
+
+// TriangulateEarClip triangulates the simple planar polygon with the vertices points
+// by ear clipping in the plane of the polygon and returns the triangles as index triples
+// into points with the same winding as the polygon.
+// Vertices that are collinear with their neighbors don't produce triangles of their own.
+// An error is returned for less than 3 points, polygons without area
+// and self-intersecting polygons.
+func TriangulateEarClip(points []T) ([][3]int, error) {
+	n := len(points)
+	if n < 3 {
+		return nil, errors.New("vec3.TriangulateEarClip: at least 3 points required")
+	}
+	normal := PolygonNormal(points)
+	if normal.IsZero() {
+		return nil, errors.New("vec3.TriangulateEarClip: degenerate polygon")
+	}
+	p := projectPolygon(points, &normal)
+
+	// Edges sharing a vertex always touch, only test the others.
+	for i := 0; i < n; i++ {
+		for j := i + 2; j < n; j++ {
+			if i == 0 && j == n-1 {
+				continue
+			}
+			if segmentsIntersect2D(&p[i], &p[(i+1)%n], &p[j], &p[(j+1)%n]) {
+				return nil, errors.New("vec3.TriangulateEarClip: self-intersecting polygon")
+			}
+		}
+	}
+
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = i
+	}
+	triangles := make([][3]int, 0, n-2)
+	for len(indices) > 3 {
+		m := len(indices)
+		clipped := false
+		for i := 0; i < m; i++ {
+			ia, ib, ic := indices[(i+m-1)%m], indices[i], indices[(i+1)%m]
+			a, b, c := &p[ia], &p[ib], &p[ic]
+			area := orient2D(a, b, c)
+			ab := vec2.Sub(b, a)
+			ac := vec2.Sub(c, a)
+			if math.Abs(area) <= 1e-12*(ab.LengthSqr()+ac.LengthSqr()) {
+				// collinear vertex, remove it without a triangle
+				indices = append(indices[:i], indices[i+1:]...)
+				clipped = true
+				break
+			}
+			if area < 0 {
+				continue // reflex vertex
+			}
+			isEar := true
+			for _, j := range indices {
+				if j == ia || j == ib || j == ic {
+					continue
+				}
+				q := &p[j]
+				if orient2D(a, b, q) >= 0 && orient2D(b, c, q) >= 0 && orient2D(c, a, q) >= 0 {
+					isEar = false
+					break
+				}
+			}
+			if isEar {
+				triangles = append(triangles, [3]int{ia, ib, ic})
+				indices = append(indices[:i], indices[i+1:]...)
+				clipped = true
+				break
+			}
+		}
+		if !clipped {
+			return nil, errors.New("vec3.TriangulateEarClip: no ear found, polygon is degenerate")
+		}
+	}
+	a, b, c := &p[indices[0]], &p[indices[1]], &p[indices[2]]
+	if orient2D(a, b, c) > 0 {
+		triangles = append(triangles, [3]int{indices[0], indices[1], indices[2]})
+	}
+	return triangles, nil
+}
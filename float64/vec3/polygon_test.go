@@ -0,0 +1,95 @@
+package vec3
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIsConvex(t *testing.T) {
+	quad := []T{{0, 0, 0}, {2, 0, 0}, {2, 1, 0}, {0, 1, 0}}
+	if !IsConvex(quad) {
+		t.Error("quad must be convex")
+	}
+	reversed := []T{quad[3], quad[2], quad[1], quad[0]}
+	if !IsConvex(reversed) {
+		t.Error("clockwise quad must be convex")
+	}
+	collinear := []T{{0, 0, 0}, {1, 0, 0}, {2, 0, 0}, {2, 1, 0}, {0, 1, 0}}
+	if !IsConvex(collinear) {
+		t.Error("quad with a collinear vertex must be convex")
+	}
+	arrow := []T{{0, 0, 0}, {2, 1, 0}, {0, 2, 0}, {0.5, 1, 0}}
+	if IsConvex(arrow) {
+		t.Error("arrow shape must be concave")
+	}
+	var star []T
+	for i := 0; i < 5; i++ {
+		angle := float64(i*2) * 2 * math.Pi / 5
+		star = append(star, T{math.Cos(angle), 0, math.Sin(angle)})
+	}
+	if IsConvex(star) {
+		t.Error("self-intersecting pentagram must not be convex")
+	}
+	if IsConvex(quad[:2]) {
+		t.Error("two points must not be convex")
+	}
+}
+
+func triangulatedArea(points []T, triangles [][3]int) float64 {
+	var area float64
+	for _, tri := range triangles {
+		e1 := Sub(&points[tri[1]], &points[tri[0]])
+		e2 := Sub(&points[tri[2]], &points[tri[0]])
+		c := Cross(&e1, &e2)
+		area += c.Length() / 2
+	}
+	return area
+}
+
+func TestTriangulateEarClip(t *testing.T) {
+	var pentagon []T
+	for i := 0; i < 5; i++ {
+		angle := float64(i) * 2 * math.Pi / 5
+		pentagon = append(pentagon, T{math.Cos(angle), 1, -math.Sin(angle)})
+	}
+	triangles, err := TriangulateEarClip(pentagon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(triangles) != 3 {
+		t.Errorf("pentagon triangulated into %d triangles, want 3", len(triangles))
+	}
+	if area, want := triangulatedArea(pentagon, triangles), 2.5*math.Sin(2*math.Pi/5); math.Abs(area-want) > EPSILON {
+		t.Errorf("triangulated pentagon area is %v, want %v", area, want)
+	}
+
+	// L-shaped concave polygon with area 3
+	concave := []T{{0, 0, 0}, {2, 0, 0}, {2, 1, 0}, {1, 1, 0}, {1, 2, 0}, {0, 2, 0}}
+	triangles, err = TriangulateEarClip(concave)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(triangles) != 4 {
+		t.Errorf("concave polygon triangulated into %d triangles, want 4", len(triangles))
+	}
+	if area := triangulatedArea(concave, triangles); math.Abs(area-3) > EPSILON {
+		t.Errorf("triangulated concave polygon area is %v, want 3", area)
+	}
+	normal := PolygonNormal(concave)
+	for _, tri := range triangles {
+		e1 := Sub(&concave[tri[1]], &concave[tri[0]])
+		e2 := Sub(&concave[tri[2]], &concave[tri[0]])
+		if c := Cross(&e1, &e2); Dot(&c, &normal) <= 0 {
+			t.Errorf("triangle %v has the wrong winding", tri)
+		}
+	}
+
+	bowtie := []T{{0, 0, 0}, {1, 1, 0}, {1, 0, 0}, {0, 1, 0}}
+	if _, err := TriangulateEarClip(bowtie); err == nil {
+		t.Error("expected an error for a self-intersecting polygon")
+	}
+	line := []T{{0, 0, 0}, {1, 0, 0}, {2, 0, 0}}
+	if _, err := TriangulateEarClip(line); err == nil {
+		t.Error("expected an error for a degenerate polygon")
+	}
+}
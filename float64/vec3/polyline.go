@@ -0,0 +1,163 @@
+package vec3
+
+// closestPointOnSegment returns the point on the segment from a to b that is closest to p.
+func closestPointOnSegment(p, a, b *T) T {
+	ab := Sub(b, a)
+	lengthSqr := ab.LengthSqr()
+	if lengthSqr == 0 {
+		return *a
+	}
+	ap := Sub(p, a)
+	t := Dot(&ap, &ab) / lengthSqr
+	if t <= 0 {
+		return *a
+	} else if t >= 1 {
+		return *b
+	}
+	ab.Scale(t)
+	return Add(a, &ab)
+}
+
+// SimplifyPath simplifies a polyline with the Ramer-Douglas-Peucker algorithm.
+// Points are removed recursively if their distance from the simplified line
+// is below epsilon. The first and last points are always preserved.
+// The returned slice is newly allocated.
+func SimplifyPath(points []T, epsilon float64) []T {
+	if len(points) < 3 {
+		return append([]T(nil), points...)
+	}
+	keep := make([]bool, len(points))
+	keep[0] = true
+	keep[len(points)-1] = true
+	simplifyPathRange(points, 0, len(points)-1, epsilon, keep)
+
+	result := make([]T, 0, len(points))
+	for i := range points {
+		if keep[i] {
+			result = append(result, points[i])
+		}
+	}
+	return result
+}
+
+func simplifyPathRange(points []T, first, last int, epsilon float64, keep []bool) {
+	maxDist := 0.0
+	index := -1
+	for i := first + 1; i < last; i++ {
+		c := closestPointOnSegment(&points[i], &points[first], &points[last])
+		if d := Distance(&points[i], &c); d > maxDist {
+			maxDist = d
+			index = i
+		}
+	}
+	if index < 0 || maxDist < epsilon {
+		return
+	}
+	keep[index] = true
+	simplifyPathRange(points, first, index, epsilon, keep)
+	simplifyPathRange(points, index, last, epsilon, keep)
+}
+
+// PolylineLength returns the sum of the segment lengths of the polyline.
+func PolylineLength(points []T) float64 {
+	length := 0.0
+	for i := 1; i < len(points); i++ {
+		length += Distance(&points[i-1], &points[i])
+	}
+	return length
+}
+
+// ResamplePolyline returns count points evenly spaced by arc length
+// along the polyline, starting with the first and ending with the last point.
+// For count 1 the first point is returned. A polyline with a single point or
+// zero length results in count copies of its first point.
+// Nil is returned for an empty polyline or a count below 1.
+func ResamplePolyline(points []T, count int) []T {
+	if len(points) == 0 || count < 1 {
+		return nil
+	}
+	result := make([]T, 0, count)
+	total := PolylineLength(points)
+	if count == 1 || total == 0 {
+		for i := 0; i < count; i++ {
+			result = append(result, points[0])
+		}
+		return result
+	}
+	step := total / float64(count-1)
+	segment := 0
+	// arc length at the start of the current segment
+	segmentStart := 0.0
+	segmentLength := Distance(&points[0], &points[1])
+	for i := 0; i < count-1; i++ {
+		target := float64(i) * step
+		for segment < len(points)-2 && target > segmentStart+segmentLength {
+			segmentStart += segmentLength
+			segment++
+			segmentLength = Distance(&points[segment], &points[segment+1])
+		}
+		t := 0.0
+		if segmentLength > 0 {
+			t = (target - segmentStart) / segmentLength
+		}
+		result = append(result, Interpolate(&points[segment], &points[segment+1], t))
+	}
+	return append(result, points[len(points)-1])
+}
+
+// ParallelTransportFrames returns rotation minimizing frames at the points of the polyline
+// computed with the double reflection method of Wang et al.
+// The tangents are the normalized central differences of the points
+// (forward and backward differences at the ends),
+// the first normal is initialNormal made perpendicular to the first tangent
+// and every following normal is transported from its predecessor without twist.
+// Every binormal is the cross product of its tangent and its normal.
+// initialNormal must not be parallel to the first tangent.
+func ParallelTransportFrames(points []T, initialNormal *T) (tangents, normals, binormals []T) {
+	n := len(points)
+	if n < 2 {
+		return nil, nil, nil
+	}
+	tangents = make([]T, n)
+	normals = make([]T, n)
+	binormals = make([]T, n)
+	for i := range points {
+		prev, next := i-1, i+1
+		if prev < 0 {
+			prev = 0
+		}
+		if next >= n {
+			next = n - 1
+		}
+		tangents[i] = Sub(&points[next], &points[prev])
+		tangents[i].Normalize()
+	}
+
+	r := tangents[0].Scaled(Dot(initialNormal, &tangents[0]))
+	normals[0] = Sub(initialNormal, &r)
+	normals[0].Normalize()
+	for i := 0; i < n-1; i++ {
+		normals[i+1] = normals[i]
+		v1 := Sub(&points[i+1], &points[i])
+		c1 := v1.LengthSqr()
+		if c1 == 0 {
+			continue
+		}
+		// reflect the frame at the bisecting plane of the two points
+		rL := v1.Scaled(-2 / c1 * Dot(&v1, &normals[i]))
+		rL.Add(&normals[i])
+		tL := v1.Scaled(-2 / c1 * Dot(&v1, &tangents[i]))
+		tL.Add(&tangents[i])
+		// reflect again so that the reflected tangent matches the next tangent
+		v2 := Sub(&tangents[i+1], &tL)
+		if c2 := v2.LengthSqr(); c2 > 0 {
+			d := v2.Scaled(-2 / c2 * Dot(&v2, &rL))
+			rL.Add(&d)
+		}
+		normals[i+1] = rL
+	}
+	for i := range binormals {
+		binormals[i] = Cross(&tangents[i], &normals[i])
+	}
+	return tangents, normals, binormals
+}
@@ -0,0 +1,100 @@
+package vec3
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSimplifyPath(t *testing.T) {
+	line := []T{{0, 0, 0}, {1, 0.001, 0}, {2, 0, 0}, {3, -0.001, 0}, {4, 0, 0}}
+	simplified := SimplifyPath(line, 0.01)
+	if len(simplified) != 2 || simplified[0] != line[0] || simplified[1] != line[4] {
+		t.Errorf("straight line simplified to %v, want its two end points", simplified)
+	}
+
+	zigzag := []T{{0, 0, 0}, {0.5, 0, 0}, {1, 0, 0}, {1, 1, 0}, {2, 1, 0}, {2, 0, 0}}
+	simplified = SimplifyPath(zigzag, 0.01)
+	want := []T{{0, 0, 0}, {1, 0, 0}, {1, 1, 0}, {2, 1, 0}, {2, 0, 0}}
+	if len(simplified) != len(want) {
+		t.Fatalf("zig-zag simplified to %v, want %v", simplified, want)
+	}
+	for i := range want {
+		if simplified[i] != want[i] {
+			t.Errorf("zig-zag simplified to %v, want %v", simplified, want)
+			break
+		}
+	}
+}
+
+func TestResamplePolyline(t *testing.T) {
+	path := []T{{0, 0, 0}, {3, 0, 0}, {3, 0, 0}, {3, 1, 0}}
+	if l := PolylineLength(path); math.Abs(l-4) > EPSILON {
+		t.Errorf("length of the L-shaped path is %v, want 4", l)
+	}
+
+	points := ResamplePolyline(path, 9)
+	if len(points) != 9 {
+		t.Fatalf("got %d points, want 9", len(points))
+	}
+	if points[0] != path[0] || points[8] != path[3] {
+		t.Errorf("end points are %v and %v, want %v and %v", points[0], points[8], path[0], path[3])
+	}
+	want := []T{{0, 0, 0}, {0.5, 0, 0}, {1, 0, 0}, {1.5, 0, 0}, {2, 0, 0}, {2.5, 0, 0}, {3, 0, 0}, {3, 0.5, 0}, {3, 1, 0}}
+	for i := range want {
+		if Distance(&points[i], &want[i]) > EPSILON {
+			t.Errorf("point %d is %v, want %v", i, points[i], want[i])
+		}
+	}
+
+	single := ResamplePolyline(path[:1], 3)
+	if len(single) != 3 || single[0] != path[0] || single[2] != path[0] {
+		t.Errorf("resampling a single point gives %v", single)
+	}
+	if r := ResamplePolyline(nil, 3); r != nil {
+		t.Errorf("resampling an empty path gives %v", r)
+	}
+}
+
+func TestParallelTransportFrames(t *testing.T) {
+	const count = 400
+	points := make([]T, count)
+	for i := range points {
+		s := float64(i) * 0.02
+		points[i] = T{2 * math.Cos(s), 2 * math.Sin(s), 0.5 * s}
+	}
+	tangents, normals, binormals := ParallelTransportFrames(points, &UnitZ)
+	if len(tangents) != count || len(normals) != count || len(binormals) != count {
+		t.Fatalf("got %d, %d, %d frames, want %d", len(tangents), len(normals), len(binormals), count)
+	}
+
+	twist := 0.0
+	for i := range points {
+		if math.Abs(normals[i].Length()-1) > EPSILON || math.Abs(Dot(&normals[i], &tangents[i])) > EPSILON {
+			t.Fatalf("normal %d %v is not a unit vector perpendicular to the tangent %v", i, normals[i], tangents[i])
+		}
+		if math.Abs(binormals[i].Length()-1) > EPSILON {
+			t.Fatalf("binormal %d %v is not a unit vector", i, binormals[i])
+		}
+		if i > 0 {
+			// a rotation minimizing frame does not rotate about the tangent,
+			// so the normal changes only towards the tangent
+			dn := Sub(&normals[i], &normals[i-1])
+			b := Add(&binormals[i], &binormals[i-1])
+			twist += Dot(&dn, &b) * 0.5
+		}
+	}
+	// the Frenet frame of this helix twists by 0.5/(2²+0.5²) per unit of the parameter,
+	// about 0.94 radians over the whole curve
+	if math.Abs(twist) > 1e-3 {
+		t.Errorf("frames accumulated a twist of %v", twist)
+	}
+
+	line := []T{{0, 0, 0}, {1, 0, 0}, {2, 0, 0}, {3, 0, 0}}
+	_, normals, _ = ParallelTransportFrames(line, &T{1, 1, 1})
+	want := T{0, math.Sqrt2 / 2, math.Sqrt2 / 2}
+	for i := range normals {
+		if Distance(&normals[i], &want) > EPSILON {
+			t.Errorf("normal %d on a straight line is %v, want %v", i, normals[i], want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package vec3
+
+import "math"
+
+// RotateAroundAxis returns v rotated by angle radians counter-clockwise
+// around the normalized axis using Rodrigues' rotation formula.
+// When rotating many vectors by the same rotation, use a Rotator
+// to avoid recomputing the sine and cosine for every vector.
+func RotateAroundAxis(v, axis *T, angle float64) T {
+	sin, cos := math.Sincos(angle)
+	kv := Cross(axis, v)
+	r := v.Scaled(cos)
+	kv.Scale(sin)
+	r.Add(&kv)
+	k := axis.Scaled(Dot(axis, v) * (1 - cos))
+	return *r.Add(&k)
+}
+
+// Rotator rotates vectors by a fixed axis-angle rotation.
+// The rotation matrix is computed once by NewRotator and stored as its three
+// column vectors, the rotated unit axes X, Y and Z.
+type Rotator struct {
+	cols [3]T
+}
+
+// NewRotator returns a Rotator for a counter-clockwise rotation by angle radians
+// around the normalized axis.
+func NewRotator(axis *T, angle float64) Rotator {
+	var r Rotator
+	for i := range r.cols {
+		var unit T
+		unit[i] = 1
+		r.cols[i] = RotateAroundAxis(&unit, axis, angle)
+	}
+	return r
+}
+
+// Apply returns v rotated by the rotation of the Rotator.
+func (r *Rotator) Apply(v *T) T {
+	return T{
+		r.cols[0][0]*v[0] + r.cols[1][0]*v[1] + r.cols[2][0]*v[2],
+		r.cols[0][1]*v[0] + r.cols[1][1]*v[1] + r.cols[2][1]*v[2],
+		r.cols[0][2]*v[0] + r.cols[1][2]*v[1] + r.cols[2][2]*v[2],
+	}
+}
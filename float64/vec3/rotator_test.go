@@ -0,0 +1,43 @@
+package vec3
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRotator(t *testing.T) {
+	if r := RotateAroundAxis(&UnitX, &UnitZ, math.Pi/2); Distance(&r, &UnitY) > 1e-12 {
+		t.Errorf("X rotated a quarter turn around Z is %v, want %v", r, UnitY)
+	}
+
+	axis := T{1, -2, 0.5}
+	axis.Normalize()
+	const angle = 2.1
+	rotator := NewRotator(&axis, angle)
+	for _, v := range []T{{1, 0, 0}, {0, 3, 0}, {-1, 2, 5}, axis} {
+		want := RotateAroundAxis(&v, &axis, angle)
+		if got := rotator.Apply(&v); Distance(&got, &want) > 1e-12 {
+			t.Errorf("Apply(%v) is %v, want %v", v, got, want)
+		}
+	}
+	if got := rotator.Apply(&axis); Distance(&got, &axis) > 1e-12 {
+		t.Errorf("rotating the axis changed it to %v", got)
+	}
+}
+
+func BenchmarkRotateAroundAxis(b *testing.B) {
+	axis := T{0, 0.6, 0.8}
+	v := T{1, 2, 3}
+	for i := 0; i < b.N; i++ {
+		v = RotateAroundAxis(&v, &axis, 0.1)
+	}
+}
+
+func BenchmarkRotatorApply(b *testing.B) {
+	axis := T{0, 0.6, 0.8}
+	v := T{1, 2, 3}
+	rotator := NewRotator(&axis, 0.1)
+	for i := 0; i < b.N; i++ {
+		v = rotator.Apply(&v)
+	}
+}
@@ -0,0 +1,147 @@
+package vec3
+
+import (
+	"math"
+	"math/rand"
+)
+
+// goldenAngle is the angle in radians that divides the full circle in the golden ratio.
+var goldenAngle = math.Pi * (3 - math.Sqrt(5))
+
+// FibonacciSphere returns count nearly uniformly distributed unit vectors
+// on the sphere using the golden angle method.
+// The result is deterministic, which makes it useful for sampling
+// directions for ambient occlusion and similar effects.
+func FibonacciSphere(count int) []T {
+	if count <= 0 {
+		return nil
+	}
+	points := make([]T, count)
+	for i := range points {
+		// Heights are sampled at the center of count equal bands
+		// so that the poles are not sampled twice.
+		y := 1 - (float64(i)+0.5)*2/float64(count)
+		radius := math.Sqrt(1 - y*y)
+		theta := goldenAngle * float64(i)
+		points[i] = T{math.Cos(theta) * radius, y, math.Sin(theta) * radius}
+	}
+	return points
+}
+
+// CirclePoint returns the point at angle on the circle around center
+// with the given radius in the plane spanned by axisU and axisV.
+// axisU and axisV must be orthonormal, angle zero corresponds to the direction of axisU
+// and positive angles rotate from axisU towards axisV.
+func CirclePoint(center, axisU, axisV *T, radius, angle float64) T {
+	u := axisU.Scaled(radius * math.Cos(angle))
+	v := axisV.Scaled(radius * math.Sin(angle))
+	result := Add(center, &u)
+	return *result.Add(&v)
+}
+
+// RandomPointInTriangle returns a random point that is uniformly distributed
+// over the area of the triangle a, b, c.
+// The barycentric coordinates are derived from two random numbers r1, r2
+// as (1-sqrt(r1), sqrt(r1)*(1-r2), sqrt(r1)*r2).
+func RandomPointInTriangle(a, b, c *T, rng *rand.Rand) T {
+	s := math.Sqrt(rng.Float64())
+	r2 := rng.Float64()
+	wa := 1 - s
+	wb := s * (1 - r2)
+	wc := s * r2
+	return T{
+		a[0]*wa + b[0]*wb + c[0]*wc,
+		a[1]*wa + b[1]*wb + c[1]*wc,
+		a[2]*wa + b[2]*wb + c[2]*wc,
+	}
+}
+
+// RandomOnDisk returns a random point that is uniformly distributed over the area
+// of the disk with the given center and radius perpendicular to the unit length normal.
+func RandomOnDisk(center, normal *T, radius float64, rng *rand.Rand) T {
+	u, v := orthonormalBasis(normal)
+	r := radius * math.Sqrt(rng.Float64())
+	return CirclePoint(center, &u, &v, r, 2*math.Pi*rng.Float64())
+}
+
+// RandomOnRectangle returns a random point that is uniformly distributed over the area
+// of the parallelogram with the given center and the full edge vectors edgeU and edgeV.
+func RandomOnRectangle(center, edgeU, edgeV *T, rng *rand.Rand) T {
+	u := edgeU.Scaled(rng.Float64() - 0.5)
+	v := edgeV.Scaled(rng.Float64() - 0.5)
+	p := Add(center, &u)
+	return *p.Add(&v)
+}
+
+// RandomInCone returns a random unit vector that is uniformly distributed
+// over the solid angle of the cone with the given half angle in radians
+// around the unit length axis.
+func RandomInCone(axis *T, halfAngle float64, rng *rand.Rand) T {
+	cosMax := math.Cos(halfAngle)
+	u, v := orthonormalBasis(axis)
+	cosTheta := 1 - rng.Float64()*(1-cosMax)
+	sinTheta := math.Sqrt(1 - cosTheta*cosTheta)
+	phi := 2 * math.Pi * rng.Float64()
+	dir := axis.Scaled(cosTheta)
+	du := u.Scaled(sinTheta * math.Cos(phi))
+	dv := v.Scaled(sinTheta * math.Sin(phi))
+	dir.Add(&du).Add(&dv)
+	return dir
+}
+
+// GlossyReflect reflects incident at the surface with the unit length normal
+// and perturbs the reflected direction by sampling uniformly within a cone
+// around it. The half angle of the cone is roughness*π/2, so a roughness of 0
+// returns the perfect mirror reflection and a roughness of 1 samples the whole hemisphere
+// around the reflection. Samples that point below the surface are re-drawn,
+// if no valid sample is found after a few attempts the perfect reflection is returned.
+// The result has unit length.
+func GlossyReflect(incident, normal *T, roughness float64, rng *rand.Rand) T {
+	reflected := Reflect(incident, normal)
+	reflected.Normalize()
+	if roughness <= 0 {
+		return reflected
+	}
+	if roughness > 1 {
+		roughness = 1
+	}
+	for attempt := 0; attempt < 16; attempt++ {
+		dir := RandomInCone(&reflected, roughness*math.Pi/2, rng)
+		if Dot(&dir, normal) > 0 {
+			return dir
+		}
+	}
+	return reflected
+}
+
+// SampleGGX returns a random microfacet normal (half vector) around the unit length normal
+// distributed according to the GGX (Trowbridge-Reitz) normal distribution function
+// with alpha = roughness², as used for importance sampling specular reflections.
+// Reflect the view direction about the result to get the light sample direction.
+// A roughness of 0 returns normal.
+func SampleGGX(normal *T, roughness float64, rng *rand.Rand) T {
+	alpha := roughness * roughness
+	r1, r2 := rng.Float64(), rng.Float64()
+	cosTheta := math.Sqrt((1 - r1) / (1 + (alpha*alpha-1)*r1))
+	sinTheta := math.Sqrt(1 - cosTheta*cosTheta)
+	phi := 2 * math.Pi * r2
+	u, v := orthonormalBasis(normal)
+	h := normal.Scaled(cosTheta)
+	du := u.Scaled(sinTheta * math.Cos(phi))
+	dv := v.Scaled(sinTheta * math.Sin(phi))
+	h.Add(&du).Add(&dv)
+	return h
+}
+
+// orthonormalBasis returns two unit vectors perpendicular
+// to each other and to the unit vector n.
+func orthonormalBasis(n *T) (u, v T) {
+	axis := UnitX
+	if math.Abs(n[0]) > 0.9 {
+		axis = UnitY
+	}
+	u = Cross(n, &axis)
+	u.Normalize()
+	v = Cross(n, &u)
+	return u, v
+}
@@ -0,0 +1,211 @@
+package vec3
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+const EPSILON = 0.000001
+
+func TestFibonacciSphere(t *testing.T) {
+	const count = 500
+	points := FibonacciSphere(count)
+	if len(points) != count {
+		t.Fatalf("FibonacciSphere(%d) returned %d points", count, len(points))
+	}
+	var mean T
+	for i := range points {
+		if l := points[i].Length(); math.Abs(l-1) > EPSILON {
+			t.Errorf("point %d is not unit length: %v", i, l)
+		}
+		mean.Add(&points[i])
+	}
+	mean.Scale(1.0 / count)
+	if l := mean.Length(); l > 0.01 {
+		t.Errorf("points are not evenly spread, mean is %v", mean)
+	}
+	if points := FibonacciSphere(0); len(points) != 0 {
+		t.Errorf("FibonacciSphere(0) returned %d points", len(points))
+	}
+}
+
+func TestCirclePoint(t *testing.T) {
+	for i := 0; i < 8; i++ {
+		angle := float64(i) * math.Pi / 4
+		p := CirclePoint(&Zero, &UnitX, &UnitY, 1, angle)
+		want := T{math.Cos(angle), math.Sin(angle), 0}
+		if Distance(&p, &want) > EPSILON {
+			t.Errorf("CirclePoint at angle %v is %v, want %v", angle, p, want)
+		}
+	}
+	center := T{1, 2, 3}
+	p := CirclePoint(&center, &UnitX, &UnitY, 2, math.Pi/2)
+	if want := (T{1, 4, 3}); Distance(&p, &want) > EPSILON {
+		t.Errorf("CirclePoint with center %v is %v, want %v", center, p, want)
+	}
+}
+
+func TestRandomPointInTriangle(t *testing.T) {
+	a := T{0, 0, 0}
+	b := T{3, 0, 0}
+	c := T{0, 3, 3}
+	rng := rand.New(rand.NewSource(1))
+	const count = 20000
+	var mean T
+	normal := Cross(&b, &c)
+	for i := 0; i < count; i++ {
+		p := RandomPointInTriangle(&a, &b, &c, rng)
+		if d := Dot(&p, &normal); math.Abs(d) > EPSILON {
+			t.Fatalf("point %v is not in the plane of the triangle", p)
+		}
+		mean.Add(&p)
+	}
+	mean.Scale(1.0 / count)
+	centroid := T{1, 1, 1}
+	if d := Distance(&mean, &centroid); d > 0.03 {
+		t.Errorf("sample mean %v is %v away from the centroid %v", mean, d, centroid)
+	}
+}
+
+func TestGlossyReflect(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	incident := T{1, -1, 0}
+	normal := UnitY
+	perfect := T{1 / math.Sqrt2, 1 / math.Sqrt2, 0}
+	if got := GlossyReflect(&incident, &normal, 0, rng); Distance(&got, &perfect) > EPSILON {
+		t.Errorf("reflection with roughness 0 is %v, want %v", got, perfect)
+	}
+
+	spread := false
+	for i := 0; i < 200; i++ {
+		dir := GlossyReflect(&incident, &normal, 0.8, rng)
+		if math.Abs(dir.Length()-1) > EPSILON {
+			t.Fatalf("glossy reflection %v is not unit length", dir)
+		}
+		if dir[1] <= 0 {
+			t.Fatalf("glossy reflection %v points below the surface", dir)
+		}
+		if Dot(&dir, &perfect) < 0.99 {
+			spread = true
+		}
+	}
+	if !spread {
+		t.Error("roughness 0.8 produced no spread around the perfect reflection")
+	}
+}
+
+func TestRandomInCone(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	axis := T{1, 2, -2}
+	axis.Normalize()
+	const halfAngle = 0.4
+	const count = 20000
+	cosMax := math.Cos(halfAngle)
+	u, _ := orthonormalBasis(&axis)
+	inner, positiveU := 0, 0
+	var mean T
+	for i := 0; i < count; i++ {
+		dir := RandomInCone(&axis, halfAngle, rng)
+		if math.Abs(dir.Length()-1) > EPSILON {
+			t.Fatalf("sample %v is not unit length", dir)
+		}
+		cos := Dot(&dir, &axis)
+		if cos < cosMax-EPSILON {
+			t.Fatalf("sample %v is %v from the axis, more than %v", dir, math.Acos(cos), halfAngle)
+		}
+		// the two halves of the spherical cap between cosMax and 1 have equal area
+		if cos > (1+cosMax)/2 {
+			inner++
+		}
+		if Dot(&dir, &u) > 0 {
+			positiveU++
+		}
+		mean.Add(&dir)
+	}
+	if f := float64(inner) / count; math.Abs(f-0.5) > 0.02 {
+		t.Errorf("%v of the samples are in the inner half of the cap, want 0.5", f)
+	}
+	if f := float64(positiveU) / count; math.Abs(f-0.5) > 0.02 {
+		t.Errorf("%v of the samples are on one side of the axis, want 0.5", f)
+	}
+	mean.Normalize()
+	if Dot(&mean, &axis) < 0.999 {
+		t.Errorf("mean direction %v deviates from the axis %v", mean, axis)
+	}
+}
+
+func TestSampleGGX(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	normal := T{0, 1, 1}
+	normal.Normalize()
+	if h := SampleGGX(&normal, 0, rng); Distance(&h, &normal) > EPSILON {
+		t.Errorf("SampleGGX with roughness 0 is %v, want %v", h, normal)
+	}
+	meanCos := func(roughness float64) float64 {
+		sum := 0.0
+		for i := 0; i < 5000; i++ {
+			h := SampleGGX(&normal, roughness, rng)
+			if math.Abs(h.Length()-1) > EPSILON {
+				t.Fatalf("half vector %v is not unit length", h)
+			}
+			cos := Dot(&h, &normal)
+			if cos < 0 {
+				t.Fatalf("half vector %v is below the surface", h)
+			}
+			sum += cos
+		}
+		return sum / 5000
+	}
+	smooth, rough := meanCos(0.05), meanCos(0.9)
+	if smooth < 0.999 {
+		t.Errorf("samples with low roughness are not concentrated around the normal: mean cosine %v", smooth)
+	}
+	if rough >= smooth {
+		t.Errorf("samples with high roughness are not spread wider: mean cosine %v >= %v", rough, smooth)
+	}
+}
+
+func TestRandomOnDiskAndRectangle(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	center := T{1, 2, 3}
+	normal := T{1, 1, 0}
+	normal.Normalize()
+	const count = 20000
+	var mean T
+	inner := 0
+	for i := 0; i < count; i++ {
+		p := RandomOnDisk(&center, &normal, 2, rng)
+		d := Sub(&p, &center)
+		if math.Abs(Dot(&d, &normal)) > EPSILON || d.Length() > 2+EPSILON {
+			t.Fatalf("point %v is not on the disk", p)
+		}
+		// the inner disk with radius √2 has half the area
+		if d.Length() < math.Sqrt2 {
+			inner++
+		}
+		mean.Add(&p)
+	}
+	mean.Scale(1.0 / count)
+	if Distance(&mean, &center) > 0.03 {
+		t.Errorf("mean of the disk samples is %v, want %v", mean, center)
+	}
+	if f := float64(inner) / count; math.Abs(f-0.5) > 0.02 {
+		t.Errorf("%v of the disk samples are in the inner half of the area, want 0.5", f)
+	}
+
+	edgeU, edgeV := T{4, 0, 0}, T{0, 0, 2}
+	mean = Zero
+	for i := 0; i < count; i++ {
+		p := RandomOnRectangle(&center, &edgeU, &edgeV, rng)
+		d := Sub(&p, &center)
+		if d[1] != 0 || math.Abs(d[0]) > 2 || math.Abs(d[2]) > 1 {
+			t.Fatalf("point %v is not on the rectangle", p)
+		}
+		mean.Add(&p)
+	}
+	mean.Scale(1.0 / count)
+	if Distance(&mean, &center) > 0.03 {
+		t.Errorf("mean of the rectangle samples is %v, want %v", mean, center)
+	}
+}
@@ -0,0 +1,81 @@
+package vec3
+
+import (
+	"math"
+)
+
+// SmoothMin returns the polynomial smooth minimum of a and b
+// as used for blending signed distance fields.
+// k is the size of the blend region, for k <= 0 the result is math.Min(a, b).
+func SmoothMin(a, b, k float64) float64 {
+	if k <= 0 {
+		return math.Min(a, b)
+	}
+	h := math.Max(k-math.Abs(a-b), 0) / k
+	return math.Min(a, b) - h*h*k*0.25
+}
+
+// SmoothMinVec returns the component wise SmoothMin of a and b.
+func SmoothMinVec(a, b *T, k float64) T {
+	return T{
+		SmoothMin(a[0], b[0], k),
+		SmoothMin(a[1], b[1], k),
+		SmoothMin(a[2], b[2], k),
+	}
+}
+
+// SDFUnion returns the signed distance of the union of two shapes
+// with the signed distances a and b.
+func SDFUnion(a, b float64) float64 {
+	return math.Min(a, b)
+}
+
+// SDFSubtract returns the signed distance of the shape with distance a
+// with the shape with distance b cut out of it.
+func SDFSubtract(a, b float64) float64 {
+	return math.Max(a, -b)
+}
+
+// SDFIntersect returns the signed distance of the intersection of two shapes
+// with the signed distances a and b.
+func SDFIntersect(a, b float64) float64 {
+	return math.Max(a, b)
+}
+
+// SDFSmoothUnion returns SDFUnion blended over a region of size k using SmoothMin.
+func SDFSmoothUnion(a, b, k float64) float64 {
+	return SmoothMin(a, b, k)
+}
+
+// SDFSmoothSubtract returns SDFSubtract blended over a region of size k.
+func SDFSmoothSubtract(a, b, k float64) float64 {
+	return -SmoothMin(-a, b, k)
+}
+
+// SDFSmoothIntersect returns SDFIntersect blended over a region of size k.
+func SDFSmoothIntersect(a, b, k float64) float64 {
+	return -SmoothMin(-a, -b, k)
+}
+
+// RayMarch marches along the ray from origin in the unit length direction dir
+// by steps of the signed distance sdf returns for the current point (sphere tracing).
+// hit is true when the distance falls below epsilon within maxSteps evaluations
+// of sdf and without travelling farther than maxDist.
+// point is the last evaluated point and steps the number of sdf evaluations.
+func RayMarch(origin, dir *T, sdf func(*T) float64, maxSteps int, maxDist, epsilon float64) (hit bool, point T, steps int) {
+	point = *origin
+	t := 0.0
+	for steps < maxSteps {
+		point = T{origin[0] + dir[0]*t, origin[1] + dir[1]*t, origin[2] + dir[2]*t}
+		d := sdf(&point)
+		steps++
+		if d < epsilon {
+			return true, point, steps
+		}
+		t += d
+		if t > maxDist {
+			break
+		}
+	}
+	return false, point, steps
+}
@@ -0,0 +1,90 @@
+package vec3
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSmoothMin(t *testing.T) {
+	if got := SmoothMin(1, 2, 0); got != 1 {
+		t.Errorf("SmoothMin with k=0 is %v, want 1", got)
+	}
+	if got := SmoothMin(1, 2, 1e-9); math.Abs(got-1) > EPSILON {
+		t.Errorf("SmoothMin with tiny k is %v, want 1", got)
+	}
+	if got := SmoothMin(1, 1, 1); got >= 1 {
+		t.Errorf("SmoothMin of equal values with k=1 is %v, want less than 1", got)
+	}
+	if got := SmoothMin(1, 1.5, 1); got >= 1 || got < 0.5 {
+		t.Errorf("SmoothMin(1, 1.5, 1) is %v, want a blend slightly below 1", got)
+	}
+	if got := SmoothMin(1, 5, 1); got != 1 {
+		t.Errorf("SmoothMin outside the blend region is %v, want 1", got)
+	}
+
+	a := T{0, 2, 1}
+	b := T{3, -1, 1}
+	if got, want := SmoothMinVec(&a, &b, 0), (T{0, -1, 1}); got != want {
+		t.Errorf("SmoothMinVec with k=0 is %v, want %v", got, want)
+	}
+}
+
+func TestSDFOperators(t *testing.T) {
+	pairs := [][2]float64{{1, 2}, {-0.5, 0.25}, {-3, -1}, {0.7, 0.7}}
+	for _, p := range pairs {
+		a, b := p[0], p[1]
+		if got, want := SDFUnion(a, b), math.Min(a, b); got != want {
+			t.Errorf("SDFUnion(%v, %v) is %v, want %v", a, b, got, want)
+		}
+		if got, want := SDFSubtract(a, b), math.Max(a, -b); got != want {
+			t.Errorf("SDFSubtract(%v, %v) is %v, want %v", a, b, got, want)
+		}
+		if got, want := SDFIntersect(a, b), math.Max(a, b); got != want {
+			t.Errorf("SDFIntersect(%v, %v) is %v, want %v", a, b, got, want)
+		}
+		if got, want := SDFSmoothUnion(a, b, 0), SDFUnion(a, b); got != want {
+			t.Errorf("SDFSmoothUnion(%v, %v, 0) is %v, want %v", a, b, got, want)
+		}
+		if got, want := SDFSmoothSubtract(a, b, 0), SDFSubtract(a, b); got != want {
+			t.Errorf("SDFSmoothSubtract(%v, %v, 0) is %v, want %v", a, b, got, want)
+		}
+		if got, want := SDFSmoothIntersect(a, b, 0), SDFIntersect(a, b); got != want {
+			t.Errorf("SDFSmoothIntersect(%v, %v, 0) is %v, want %v", a, b, got, want)
+		}
+	}
+
+	// inside the blend region the smooth union lies below and the smooth intersection above the hard one
+	if got := SDFSmoothUnion(0.7, 0.7, 0.5); got >= 0.7 {
+		t.Errorf("SDFSmoothUnion of equal distances is %v, want less than 0.7", got)
+	}
+	if got := SDFSmoothIntersect(0.7, 0.7, 0.5); got <= 0.7 {
+		t.Errorf("SDFSmoothIntersect of equal distances is %v, want more than 0.7", got)
+	}
+	if got := SDFSmoothSubtract(0.7, -0.7, 0.5); got <= 0.7 {
+		t.Errorf("SDFSmoothSubtract is %v, want more than 0.7", got)
+	}
+}
+
+func TestRayMarch(t *testing.T) {
+	center := T{0, 0, 10}
+	sphere := func(p *T) float64 {
+		return Distance(p, &center) - 2
+	}
+	dir := T{0.1, 0.05, 1}
+	dir.Normalize()
+	hit, point, steps := RayMarch(&Zero, &dir, sphere, 100, 100, 1e-7)
+	if !hit {
+		t.Fatalf("ray missed the sphere after %d steps", steps)
+	}
+	if d := Distance(&point, &center); math.Abs(d-2) > EPSILON {
+		t.Errorf("hit point %v is %v away from the center, want 2", point, d)
+	}
+
+	up := UnitY
+	if hit, _, _ := RayMarch(&Zero, &up, sphere, 100, 100, 1e-7); hit {
+		t.Errorf("ray away from the sphere hit it")
+	}
+	if hit, _, steps := RayMarch(&Zero, &dir, sphere, 2, 100, 1e-7); hit || steps != 2 {
+		t.Errorf("RayMarch with 2 steps returned hit %v after %d steps", hit, steps)
+	}
+}
@@ -0,0 +1,192 @@
+package vec3
+
+import (
+	"math"
+)
+
+// FresnelSchlick returns Schlick's approximation f0 + (1-f0)*(1-cosTheta)⁵
+// of the Fresnel reflectance for the cosine of the angle between the view
+// direction and the surface normal cosTheta and the reflectance at normal incidence f0.
+// cosTheta is clamped to [0,1].
+func FresnelSchlick(cosTheta, f0 float64) float64 {
+	if cosTheta < 0 {
+		cosTheta = 0
+	} else if cosTheta > 1 {
+		cosTheta = 1
+	}
+	m := 1 - cosTheta
+	m2 := m * m
+	return f0 + (1-f0)*m2*m2*m
+}
+
+// FresnelSchlickVec returns FresnelSchlick for every color channel of f0.
+func FresnelSchlickVec(cosTheta float64, f0 *T) T {
+	return T{
+		FresnelSchlick(cosTheta, f0[0]),
+		FresnelSchlick(cosTheta, f0[1]),
+		FresnelSchlick(cosTheta, f0[2]),
+	}
+}
+
+// BentNormal accumulates ambient occlusion samples
+// to compute the bent normal, the average unoccluded direction.
+// The zero value is an empty accumulator.
+type BentNormal struct {
+	sum     T
+	visible int
+	count   int
+}
+
+// AddSample adds the sample direction dir, visible tells if the direction is unoccluded.
+func (bn *BentNormal) AddSample(dir *T, visible bool) {
+	bn.count++
+	if visible {
+		bn.visible++
+		bn.sum.Add(dir)
+	}
+}
+
+// Result returns the normalized average of the visible sample directions as bentNormal
+// and the fraction of visible samples as openness.
+// bentNormal is the zero vector if no sample is visible.
+func (bn *BentNormal) Result() (bentNormal T, openness float64) {
+	if bn.count == 0 {
+		return Zero, 0
+	}
+	bentNormal = bn.sum
+	if bn.visible > 0 {
+		bentNormal.Normalize()
+	}
+	return bentNormal, float64(bn.visible) / float64(bn.count)
+}
+
+// Reflect returns incident reflected at the surface with the unit length normal.
+func Reflect(incident, normal *T) T {
+	r := normal.Scaled(-2 * Dot(incident, normal))
+	return *r.Add(incident)
+}
+
+// ReflectAbout returns v mirrored about the line through the unit length axis,
+// computing 2*(v·axis)*axis - v. Unlike Reflect, which mirrors the incoming
+// direction at the surface, both v and the result point away from the surface,
+// as for the view and light directions in shading.
+func ReflectAbout(v, axis *T) T {
+	r := axis.Scaled(2 * Dot(v, axis))
+	return *r.Sub(v)
+}
+
+// HalfVector returns the normalized sum of the unit length directions
+// from the surface to the viewer and to the light,
+// which bisects the angle between them.
+func HalfVector(viewDir, lightDir *T) T {
+	h := Add(viewDir, lightDir)
+	return *h.Normalize()
+}
+
+// ClampedDot returns the dot product of a and b clamped to be at least zero,
+// as used for shading terms like N·L and N·H.
+func ClampedDot(a, b *T) float64 {
+	return math.Max(Dot(a, b), 0)
+}
+
+// Refract returns the unit length incident refracted at the surface with the unit length normal
+// that points against incident, where eta is the ratio of the index of refraction
+// of the medium incident comes from to the index of the medium it enters.
+// ok is false in the case of total internal reflection.
+func Refract(incident, normal *T, eta float64) (refracted T, ok bool) {
+	cosI := -Dot(incident, normal)
+	k := 1 - eta*eta*(1-cosI*cosI)
+	if k < 0 {
+		return Zero, false
+	}
+	refracted = incident.Scaled(eta)
+	n := normal.Scaled(eta*cosI - math.Sqrt(k))
+	refracted.Add(&n)
+	return refracted, true
+}
+
+// RefractionStack tracks the indices of refraction of the nested media
+// a ray travels through and refracts the ray when it enters or exits a medium.
+// Create it with NewRefractionStack.
+type RefractionStack struct {
+	indices []float64
+}
+
+// NewRefractionStack returns a RefractionStack for a ray starting in the medium
+// with the index of refraction outside, for example 1 for air.
+// The outermost medium is never exited.
+func NewRefractionStack(outside float64) *RefractionStack {
+	return &RefractionStack{indices: []float64{outside}}
+}
+
+// Current returns the index of refraction of the medium the ray is in.
+func (rs *RefractionStack) Current() float64 {
+	return rs.indices[len(rs.indices)-1]
+}
+
+// Enter refracts the unit length direction dir into the medium with the index of refraction ior
+// at the surface with the unit length outward normal of that medium.
+// In the case of total internal reflection the reflected direction is returned,
+// entered is false and the ray stays in the current medium.
+func (rs *RefractionStack) Enter(dir, normal *T, ior float64) (newDir T, entered bool) {
+	newDir, entered = Refract(dir, normal, rs.Current()/ior)
+	if !entered {
+		return Reflect(dir, normal), false
+	}
+	rs.indices = append(rs.indices, ior)
+	return newDir, true
+}
+
+// Exit refracts the unit length direction dir out of the current medium
+// into the medium it is nested in at the surface with the unit length outward normal
+// of the current medium.
+// In the case of total internal reflection the reflected direction is returned,
+// exited is false and the ray stays in the current medium.
+// Exiting the outermost medium does not change dir.
+func (rs *RefractionStack) Exit(dir, normal *T) (newDir T, exited bool) {
+	n := len(rs.indices)
+	if n == 1 {
+		return *dir, false
+	}
+	inward := normal.Inverted()
+	newDir, exited = Refract(dir, &inward, rs.indices[n-1]/rs.indices[n-2])
+	if !exited {
+		return Reflect(dir, &inward), false
+	}
+	rs.indices = rs.indices[:n-1]
+	return newDir, true
+}
+
+// EvalSHIrradiance returns the RGB irradiance for the surface normal from the
+// L2 spherical harmonics projection coeffs of the incoming radiance,
+// per Ramamoorthi and Hanrahan, An Efficient Representation for Irradiance Environment Maps.
+// The coefficients are ordered L00, L1-1, L10, L11, L2-2, L2-1, L20, L21, L22
+// for the real basis functions of y, z, x, xy, yz, 3z²-1, xz and x²-y²
+// and are convolved with the clamped cosine lobe weights π, 2π/3 and π/4 per band.
+// A constant radiance c projects to L00 = 2√π·c and results in the irradiance π·c.
+// normal must be normalized.
+func EvalSHIrradiance(coeffs *[9]T, normal *T) T {
+	x, y, z := normal[0], normal[1], normal[2]
+	const (
+		a0 = math.Pi
+		a1 = 2 * math.Pi / 3
+		a2 = math.Pi / 4
+	)
+	weights := [9]float64{
+		a0 * 0.282095,
+		a1 * 0.488603 * y,
+		a1 * 0.488603 * z,
+		a1 * 0.488603 * x,
+		a2 * 1.092548 * x * y,
+		a2 * 1.092548 * y * z,
+		a2 * 0.315392 * (3*z*z - 1),
+		a2 * 1.092548 * x * z,
+		a2 * 0.546274 * (x*x - y*y),
+	}
+	var result T
+	for i := range coeffs {
+		c := coeffs[i].Scaled(weights[i])
+		result.Add(&c)
+	}
+	return result
+}
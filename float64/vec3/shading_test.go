@@ -0,0 +1,142 @@
+package vec3
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestFresnelSchlick(t *testing.T) {
+	if r := FresnelSchlick(1, 0.04); math.Abs(r-0.04) > EPSILON {
+		t.Errorf("reflectance at normal incidence is %v, want 0.04", r)
+	}
+	if r := FresnelSchlick(0, 0.04); math.Abs(r-1) > EPSILON {
+		t.Errorf("reflectance at grazing incidence is %v, want 1", r)
+	}
+	if a, b := FresnelSchlick(0.5, 0.04), FresnelSchlick(0.1, 0.04); !(a < b && b < 1) {
+		t.Errorf("reflectance must increase towards grazing angles: %v, %v", a, b)
+	}
+
+	gold := T{1, 0.71, 0.29}
+	if r := FresnelSchlickVec(1, &gold); Distance(&r, &gold) > EPSILON {
+		t.Errorf("colored reflectance at normal incidence is %v, want %v", r, gold)
+	}
+	if r, want := FresnelSchlickVec(0.001, &gold), (T{1, 1, 1}); Distance(&r, &want) > 0.01 {
+		t.Errorf("colored reflectance at grazing incidence is %v, want about %v", r, want)
+	}
+}
+
+func TestBentNormal(t *testing.T) {
+	var bn BentNormal
+	if n, openness := bn.Result(); n != Zero || openness != 0 {
+		t.Errorf("empty BentNormal returned %v, %v", n, openness)
+	}
+
+	// hemisphere around UnitZ with everything on the negative X side occluded
+	for _, dir := range FibonacciSphere(1000) {
+		if dir[2] <= 0 || dir[0] == 0 {
+			continue
+		}
+		bn.AddSample(&dir, dir[0] > 0)
+	}
+	n, openness := bn.Result()
+	if math.Abs(openness-0.5) > 0.02 {
+		t.Errorf("openness is %v, want 0.5", openness)
+	}
+	if math.Abs(n.Length()-1) > EPSILON {
+		t.Errorf("bent normal %v is not unit length", n)
+	}
+	if n[0] < 0.5 || n[2] < 0.5 || math.Abs(n[1]) > 0.05 {
+		t.Errorf("bent normal %v does not tilt towards positive X", n)
+	}
+}
+
+func TestRefractionStack(t *testing.T) {
+	// glass slab between z = 0 and z = -1
+	top, bottom := UnitZ, T{0, 0, -1}
+	dir := T{0.6, 0.1, -1}
+	dir.Normalize()
+
+	rs := NewRefractionStack(1)
+	inside, entered := rs.Enter(&dir, &top, 1.5)
+	if !entered || rs.Current() != 1.5 {
+		t.Fatalf("ray did not enter the glass: %v, %v", entered, rs.Current())
+	}
+	// Snell's law: sin(theta_1) * n1 = sin(theta_2) * n2
+	sinIn := math.Hypot(dir[0], dir[1])
+	sinInside := math.Hypot(inside[0], inside[1]) / inside.Length()
+	if math.Abs(sinIn-1.5*sinInside) > EPSILON {
+		t.Errorf("refraction violates Snell's law: %v != 1.5 * %v", sinIn, sinInside)
+	}
+	out, exited := rs.Exit(&inside, &bottom)
+	if !exited || rs.Current() != 1 {
+		t.Fatalf("ray did not exit the glass: %v, %v", exited, rs.Current())
+	}
+	if Distance(&out, &dir) > EPSILON {
+		t.Errorf("exit direction %v is not parallel to the entry direction %v", out, dir)
+	}
+
+	// total internal reflection at a grazing angle from inside the glass
+	rs = NewRefractionStack(1)
+	rs.Enter(&T{0, 0, -1}, &top, 1.5)
+	grazing := T{0.9, 0, -0.1}
+	grazing.Normalize()
+	reflected, exited := rs.Exit(&grazing, &bottom)
+	if exited || rs.Current() != 1.5 {
+		t.Errorf("expected total internal reflection, exited=%v", exited)
+	}
+	if want := (T{grazing[0], 0, -grazing[2]}); Distance(&reflected, &want) > EPSILON {
+		t.Errorf("reflected direction is %v, want %v", reflected, want)
+	}
+}
+
+func TestHalfVector(t *testing.T) {
+	view := T{1, 2, 0.5}
+	light := T{-2, 1, 1}
+	view.Normalize()
+	light.Normalize()
+	h := HalfVector(&view, &light)
+	if math.Abs(h.Length()-1) > EPSILON {
+		t.Errorf("half vector %v is not unit length", h)
+	}
+	if a, b := Angle(&h, &view), Angle(&h, &light); math.Abs(a-b) > EPSILON {
+		t.Errorf("half vector %v does not bisect: %v != %v", h, a, b)
+	}
+	// the light direction is the view direction reflected about the half vector
+	if r := ReflectAbout(&view, &h); Distance(&r, &light) > EPSILON {
+		t.Errorf("view reflected about the half vector is %v, want %v", r, light)
+	}
+
+	if d := ClampedDot(&UnitX, &T{-1, 1, 0}); d != 0 {
+		t.Errorf("ClampedDot of opposing vectors is %v, want 0", d)
+	}
+	if d := ClampedDot(&UnitX, &T{0.5, 1, 0}); d != 0.5 {
+		t.Errorf("ClampedDot is %v, want 0.5", d)
+	}
+}
+
+func TestEvalSHIrradiance(t *testing.T) {
+	// constant radiance per color channel
+	radiance := T{1, 0.5, 0.25}
+	var coeffs [9]T
+	coeffs[0] = radiance.Scaled(2 * math.Sqrt(math.Pi))
+	want := radiance.Scaled(math.Pi)
+	rng := rand.New(rand.NewSource(3))
+	for i := 0; i < 20; i++ {
+		n := T{rng.NormFloat64(), rng.NormFloat64(), rng.NormFloat64()}
+		n.Normalize()
+		if got := EvalSHIrradiance(&coeffs, &n); Distance(&got, &want) > 1e-5 {
+			t.Errorf("irradiance of constant radiance for %v is %v, want %v", n, got, want)
+		}
+	}
+
+	// the radiance z projects onto L10 only and results in the irradiance 2π/3·n_z
+	coeffs = [9]T{}
+	l10 := math.Sqrt(4 * math.Pi / 3)
+	coeffs[2] = T{l10, l10, l10}
+	n := T{0.6, 0, 0.8}
+	got := EvalSHIrradiance(&coeffs, &n)
+	if w := 2 * math.Pi / 3 * 0.8; math.Abs(got[0]-w) > 1e-5 {
+		t.Errorf("irradiance of linear radiance is %v, want %v", got[0], w)
+	}
+}
@@ -0,0 +1,157 @@
+package vec3
+
+import (
+	"math"
+	"math/rand"
+)
+
+// RitterBoundingSphere returns a sphere containing all points
+// using Ritter's two-pass algorithm.
+// The result is approximate, it is usually a few percent larger
+// than the minimal bounding sphere. See WelzlBoundingSphere for the exact solution.
+// For an empty slice a zero radius sphere around the origin is returned.
+func RitterBoundingSphere(points []T) (center T, radius float64) {
+	if len(points) == 0 {
+		return Zero, 0
+	}
+	// find an approximately most distant pair of points
+	y := farthestPoint(points, &points[0])
+	z := farthestPoint(points, y)
+	center = Interpolate(y, z, 0.5)
+	radius = Distance(y, z) * 0.5
+
+	// grow the sphere to include all points outside of it
+	for i := range points {
+		dist := Distance(&points[i], &center)
+		if dist <= radius {
+			continue
+		}
+		newRadius := (radius + dist) * 0.5
+		d := Sub(&points[i], &center)
+		d.Scale((newRadius - radius) / dist)
+		center.Add(&d)
+		radius = newRadius
+	}
+	return center, radius
+}
+
+// farthestPoint returns a pointer to the element of the non empty points
+// with the largest distance from from, the first one for equal distances.
+func farthestPoint(points []T, from *T) *T {
+	farthest := &points[0]
+	maxDist := -math.MaxFloat64
+	for i := range points {
+		if d := SquareDistance(&points[i], from); d > maxDist {
+			maxDist = d
+			farthest = &points[i]
+		}
+	}
+	return farthest
+}
+
+// WelzlBoundingSphere returns the minimal sphere containing all points
+// using Welzl's randomized algorithm.
+// The points are processed in a random order drawn from rng which makes the
+// expected running time linear in the number of points.
+// The points slice itself is not modified.
+// For an empty slice a zero radius sphere around the origin is returned.
+func WelzlBoundingSphere(points []T, rng *rand.Rand) (center T, radius float64) {
+	if len(points) == 0 {
+		return Zero, 0
+	}
+	p := make([]T, len(points))
+	copy(p, points)
+	rng.Shuffle(len(p), func(i, j int) { p[i], p[j] = p[j], p[i] })
+
+	// iterative move-to-front formulation of the recursion
+	// with up to four points on the boundary of the sphere
+	center, radius = p[0], 0
+	for i := 1; i < len(p); i++ {
+		if sphereContains(&center, radius, &p[i]) {
+			continue
+		}
+		center, radius = p[i], 0
+		for j := 0; j < i; j++ {
+			if sphereContains(&center, radius, &p[j]) {
+				continue
+			}
+			center, radius = sphereFrom2(&p[i], &p[j])
+			for k := 0; k < j; k++ {
+				if sphereContains(&center, radius, &p[k]) {
+					continue
+				}
+				center, radius = sphereFrom3(&p[i], &p[j], &p[k])
+				for l := 0; l < k; l++ {
+					if sphereContains(&center, radius, &p[l]) {
+						continue
+					}
+					center, radius = sphereFrom4(&p[i], &p[j], &p[k], &p[l])
+				}
+			}
+		}
+	}
+	return center, radius
+}
+
+func sphereContains(center *T, radius float64, p *T) bool {
+	return Distance(center, p) <= radius+1e-12*(1+radius)
+}
+
+func sphereFrom2(a, b *T) (center T, radius float64) {
+	return Interpolate(a, b, 0.5), Distance(a, b) * 0.5
+}
+
+// sphereFrom3 returns the smallest sphere with a, b and c on its boundary.
+func sphereFrom3(a, b, c *T) (center T, radius float64) {
+	ab := Sub(b, a)
+	ac := Sub(c, a)
+	n := Cross(&ab, &ac)
+	nn := n.LengthSqr()
+	if nn < 1e-24 {
+		// collinear, the sphere around the two most distant points contains the third
+		center, radius = sphereFrom2(a, b)
+		if c2, r2 := sphereFrom2(a, c); r2 > radius {
+			center, radius = c2, r2
+		}
+		if c2, r2 := sphereFrom2(b, c); r2 > radius {
+			center, radius = c2, r2
+		}
+		return center, radius
+	}
+	nab := Cross(&n, &ab)
+	acn := Cross(&ac, &n)
+	nab.Scale(ac.LengthSqr())
+	acn.Scale(ab.LengthSqr())
+	offset := Add(&nab, &acn)
+	offset.Scale(1 / (2 * nn))
+	return Add(a, &offset), offset.Length()
+}
+
+// sphereFrom4 returns the sphere with a, b, c and d on its boundary.
+func sphereFrom4(a, b, c, d *T) (center T, radius float64) {
+	d1 := Sub(b, a)
+	d2 := Sub(c, a)
+	d3 := Sub(d, a)
+	c23 := Cross(&d2, &d3)
+	denom := 2 * Dot(&d1, &c23)
+	if math.Abs(denom) < 1e-24 {
+		// coplanar, use the smallest sphere through three of the points containing the fourth
+		radius = math.Inf(1)
+		candidates := [4][4]*T{{a, b, c, d}, {a, b, d, c}, {a, c, d, b}, {b, c, d, a}}
+		for _, cand := range candidates {
+			c3, r3 := sphereFrom3(cand[0], cand[1], cand[2])
+			if r3 < radius && sphereContains(&c3, r3, cand[3]) {
+				center, radius = c3, r3
+			}
+		}
+		return center, radius
+	}
+	c31 := Cross(&d3, &d1)
+	c12 := Cross(&d1, &d2)
+	c23.Scale(d1.LengthSqr())
+	c31.Scale(d2.LengthSqr())
+	c12.Scale(d3.LengthSqr())
+	offset := Add(&c23, &c31)
+	offset.Add(&c12).Scale(1 / denom)
+	return Add(a, &offset), offset.Length()
+}
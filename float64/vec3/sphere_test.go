@@ -0,0 +1,69 @@
+package vec3
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestRitterBoundingSphere(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	points := make([]T, 500)
+	for i := range points {
+		points[i] = T{rng.Float64()*2 - 1, rng.Float64()*2 - 1, rng.Float64()*2 - 1}
+	}
+	center, radius := RitterBoundingSphere(points)
+	for i := range points {
+		if d := Distance(&points[i], &center); d > radius+EPSILON {
+			t.Fatalf("point %v is outside of the sphere (distance %v > radius %v)", points[i], d, radius)
+		}
+	}
+	// the minimal sphere of the cube has the radius sqrt(3)
+	if radius > math.Sqrt(3)*1.1 {
+		t.Errorf("radius %v is not reasonably tight", radius)
+	}
+}
+
+func TestWelzlBoundingSphere(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	a, b := T{1, 2, 3}, T{3, 2, 3}
+	center, radius := WelzlBoundingSphere([]T{a, b}, rng)
+	if want := (T{2, 2, 3}); Distance(&center, &want) > EPSILON || math.Abs(radius-1) > EPSILON {
+		t.Errorf("sphere of two points is %v r=%v, want %v r=1", center, radius, want)
+	}
+
+	// an inner point must not influence the result
+	octahedron := []T{{1, 0, 0}, {-1, 0, 0}, {0, 1, 0}, {0, -1, 0}, {0, 0, 1}, {0, 0, -1}, {0.2, 0.1, -0.3}}
+	center, radius = WelzlBoundingSphere(octahedron, rng)
+	if Distance(&center, &Zero) > EPSILON || math.Abs(radius-1) > EPSILON {
+		t.Errorf("sphere of the octahedron is %v r=%v, want origin r=1", center, radius)
+	}
+
+	// equilateral triangle, the minimal sphere is its circumcircle
+	triangle := []T{{1, 0, 0}, {-0.5, math.Sqrt(3) / 2, 0}, {-0.5, -math.Sqrt(3) / 2, 0}}
+	center, radius = WelzlBoundingSphere(triangle, rng)
+	if Distance(&center, &Zero) > EPSILON || math.Abs(radius-1) > EPSILON {
+		t.Errorf("sphere of the triangle is %v r=%v, want origin r=1", center, radius)
+	}
+
+	points := make([]T, 300)
+	for i := range points {
+		points[i] = T{rng.NormFloat64(), rng.NormFloat64(), rng.NormFloat64()}
+	}
+	center, radius = WelzlBoundingSphere(points, rng)
+	for i := range points {
+		if d := Distance(&points[i], &center); d > radius+EPSILON {
+			t.Fatalf("point %v is outside of the sphere (distance %v > radius %v)", points[i], d, radius)
+		}
+	}
+	if _, ritterRadius := RitterBoundingSphere(points); radius > ritterRadius+EPSILON {
+		t.Errorf("minimal radius %v is larger than Ritter's approximation %v", radius, ritterRadius)
+	}
+	// the result does not depend on the processing order
+	for seed := int64(0); seed < 3; seed++ {
+		c, r := WelzlBoundingSphere(points, rand.New(rand.NewSource(seed)))
+		if Distance(&c, &center) > EPSILON || math.Abs(r-radius) > EPSILON {
+			t.Fatalf("sphere with seed %d is %v r=%v, want %v r=%v", seed, c, r, center, radius)
+		}
+	}
+}
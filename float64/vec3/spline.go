@@ -0,0 +1,34 @@
+package vec3
+
+// Hermite returns the point at s (0,1) of the cubic Hermite curve
+// from p0 with the tangent t0 to p1 with the tangent t1.
+// See also package hermit3.
+func Hermite(p0, t0, p1, t1 *T, s float64) T {
+	s2 := s * s
+	s3 := s2 * s
+	h00 := 2*s3 - 3*s2 + 1
+	h10 := s3 - 2*s2 + s
+	h01 := -2*s3 + 3*s2
+	h11 := s3 - s2
+	return T{
+		h00*p0[0] + h10*t0[0] + h01*p1[0] + h11*t1[0],
+		h00*p0[1] + h10*t0[1] + h01*p1[1] + h11*t1[1],
+		h00*p0[2] + h10*t0[2] + h01*p1[2] + h11*t1[2],
+	}
+}
+
+// HermiteTangent returns the first derivative with respect to s at s (0,1)
+// of the cubic Hermite curve from p0 with the tangent t0 to p1 with the tangent t1.
+// It is the velocity along the curve and gives the facing direction for path following.
+func HermiteTangent(p0, t0, p1, t1 *T, s float64) T {
+	s2 := s * s
+	h00 := 6*s2 - 6*s
+	h10 := 3*s2 - 4*s + 1
+	h01 := -6*s2 + 6*s
+	h11 := 3*s2 - 2*s
+	return T{
+		h00*p0[0] + h10*t0[0] + h01*p1[0] + h11*t1[0],
+		h00*p0[1] + h10*t0[1] + h01*p1[1] + h11*t1[1],
+		h00*p0[2] + h10*t0[2] + h01*p1[2] + h11*t1[2],
+	}
+}
@@ -0,0 +1,29 @@
+package vec3
+
+import (
+	"testing"
+)
+
+func TestHermiteTangent(t *testing.T) {
+	p0 := T{0, 0, 0}
+	t0 := T{1, 2, 0}
+	p1 := T{3, 1, -1}
+	t1 := T{0, -1, 4}
+
+	if got := HermiteTangent(&p0, &t0, &p1, &t1, 0); got != t0 {
+		t.Errorf("tangent at s=0 is %v, want %v", got, t0)
+	}
+	if got := HermiteTangent(&p0, &t0, &p1, &t1, 1); got != t1 {
+		t.Errorf("tangent at s=1 is %v, want %v", got, t1)
+	}
+	const h = 1e-6
+	for _, s := range []float64{0.1, 0.3, 0.5, 0.8} {
+		a := Hermite(&p0, &t0, &p1, &t1, s-h)
+		b := Hermite(&p0, &t0, &p1, &t1, s+h)
+		numerical := Sub(&b, &a)
+		numerical.Scale(1 / (2 * h))
+		if got := HermiteTangent(&p0, &t0, &p1, &t1, s); Distance(&got, &numerical) > 0.00001 {
+			t.Errorf("tangent at s=%v is %v, numerical derivative is %v", s, got, numerical)
+		}
+	}
+}
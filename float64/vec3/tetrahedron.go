@@ -0,0 +1,53 @@
+package vec3
+
+// signedVolume returns six times the signed volume of the tetrahedron a, b, c, d.
+func signedVolume(a, b, c, d *T) float64 {
+	ab := Sub(b, a)
+	ac := Sub(c, a)
+	ad := Sub(d, a)
+	cross := Cross(&ac, &ad)
+	return Dot(&ab, &cross)
+}
+
+// PointInTetrahedron returns if p is inside the tetrahedron a, b, c, d.
+// The test compares the signs of the signed volumes of the four sub-tetrahedra
+// formed by replacing one vertex with p against the sign of the whole tetrahedron.
+// The test is inclusive: points exactly on a face, edge or vertex are inside.
+// A degenerate tetrahedron with zero volume contains no points.
+func PointInTetrahedron(p, a, b, c, d *T) bool {
+	v := signedVolume(a, b, c, d)
+	if v == 0 {
+		return false
+	}
+	for _, sub := range [4]float64{
+		signedVolume(p, b, c, d),
+		signedVolume(a, p, c, d),
+		signedVolume(a, b, p, d),
+		signedVolume(a, b, c, p),
+	} {
+		if sub*v < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// TetrahedronBarycentric returns the barycentric weights of p relative to the
+// vertices a, b, c, d of a tetrahedron, so that p = a*w[0] + b*w[1] + c*w[2] + d*w[3].
+// The weights are the ratios of the signed volumes of the sub-tetrahedra
+// formed by replacing one vertex with p and the volume of the whole tetrahedron.
+// They always sum to 1 and are all in the range [0,1] for points inside the tetrahedron.
+// A degenerate tetrahedron with zero volume returns zero weights.
+func TetrahedronBarycentric(a, b, c, d, p *T) [4]float64 {
+	v := signedVolume(a, b, c, d)
+	if v == 0 {
+		return [4]float64{}
+	}
+	oov := 1 / v
+	return [4]float64{
+		signedVolume(p, b, c, d) * oov,
+		signedVolume(a, p, c, d) * oov,
+		signedVolume(a, b, p, d) * oov,
+		signedVolume(a, b, c, p) * oov,
+	}
+}
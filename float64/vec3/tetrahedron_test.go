@@ -0,0 +1,67 @@
+package vec3
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPointInTetrahedron(t *testing.T) {
+	a := T{0, 0, 0}
+	b := T{1, 0, 0}
+	c := T{0, 1, 0}
+	d := T{0, 0, 1}
+	centroid := T{0.25, 0.25, 0.25}
+	if !PointInTetrahedron(&centroid, &a, &b, &c, &d) {
+		t.Error("centroid must be inside")
+	}
+	if !PointInTetrahedron(&centroid, &a, &c, &b, &d) {
+		t.Error("centroid must be inside independent of the vertex order")
+	}
+	if !PointInTetrahedron(&b, &a, &b, &c, &d) {
+		t.Error("vertex must be inside")
+	}
+	onFace := T{0.2, 0.2, 0}
+	if !PointInTetrahedron(&onFace, &a, &b, &c, &d) {
+		t.Error("point on a face must be inside")
+	}
+	outside := T{1, 1, 1}
+	if PointInTetrahedron(&outside, &a, &b, &c, &d) {
+		t.Error("external point must be outside")
+	}
+	below := T{0.1, 0.1, -0.01}
+	if PointInTetrahedron(&below, &a, &b, &c, &d) {
+		t.Error("point below the base must be outside")
+	}
+}
+
+func TestTetrahedronBarycentric(t *testing.T) {
+	vertices := [4]T{{1, 0, 0}, {3, 1, 0}, {1, 2, 1}, {2, 1, 4}}
+	a, b, c, d := &vertices[0], &vertices[1], &vertices[2], &vertices[3]
+	for i := range vertices {
+		w := TetrahedronBarycentric(a, b, c, d, &vertices[i])
+		for j := range w {
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if math.Abs(w[j]-want) > EPSILON {
+				t.Errorf("weights of vertex %d are %v", i, w)
+				break
+			}
+		}
+	}
+
+	centroid := Add(a, b)
+	centroid.Add(c).Add(d).Scale(0.25)
+	for _, w := range TetrahedronBarycentric(a, b, c, d, &centroid) {
+		if math.Abs(w-0.25) > EPSILON {
+			t.Errorf("weight of the centroid is %v, want 0.25", w)
+		}
+	}
+
+	outside := T{-5, 3, 2}
+	w := TetrahedronBarycentric(a, b, c, d, &outside)
+	if sum := w[0] + w[1] + w[2] + w[3]; math.Abs(sum-1) > EPSILON {
+		t.Errorf("weights of an outside point sum to %v, want 1", sum)
+	}
+}
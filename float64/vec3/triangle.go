@@ -0,0 +1,188 @@
+package vec3
+
+import (
+	"errors"
+	"math"
+
+	"github.com/ungerik/go3d/float64/vec2"
+)
+
+// ComputeTangent computes the normalized tangent and bitangent of the triangle p0, p1, p2
+// with the texture coordinates uv0, uv1, uv2 as used for normal mapping.
+// The tangent points in the direction of increasing U, the bitangent in the direction of increasing V.
+// Zero vectors are returned if the texture coordinates are degenerate.
+func ComputeTangent(p0, p1, p2 *T, uv0, uv1, uv2 *vec2.T) (tangent, bitangent T) {
+	e1 := Sub(p1, p0)
+	e2 := Sub(p2, p0)
+	du1 := uv1[0] - uv0[0]
+	dv1 := uv1[1] - uv0[1]
+	du2 := uv2[0] - uv0[0]
+	dv2 := uv2[1] - uv0[1]
+
+	det := du1*dv2 - du2*dv1
+	if math.Abs(det) < 1e-12 {
+		return Zero, Zero
+	}
+	r := 1 / det
+	for i := range tangent {
+		tangent[i] = (e1[i]*dv2 - e2[i]*dv1) * r
+		bitangent[i] = (e2[i]*du1 - e1[i]*du2) * r
+	}
+	tangent.Normalize()
+	bitangent.Normalize()
+	return tangent, bitangent
+}
+
+// DihedralAngle returns the signed angle between the triangles (a, b, c) and (a, b, d)
+// across their shared edge from a to b.
+// The angle is measured from the half-plane containing c to the half-plane containing d,
+// counter-clockwise when looking down the edge axis from b towards a (right-hand rule about b-a).
+// The result is in the range -Pi to Pi: Pi for a flat configuration with c and d on opposite sides
+// of the edge and 0 if both triangles are folded onto each other.
+func DihedralAngle(a, b, c, d *T) float64 {
+	edge := Sub(b, a)
+	edge.Normalize()
+	u := Sub(c, a)
+	ue := edge.Scaled(Dot(&u, &edge))
+	u.Sub(&ue)
+	w := Sub(d, a)
+	we := edge.Scaled(Dot(&w, &edge))
+	w.Sub(&we)
+	cross := Cross(&u, &w)
+	return math.Atan2(Dot(&edge, &cross), Dot(&u, &w))
+}
+
+// ConsistentNormal returns the unit normal of the triangle a, b, c
+// (counter-clockwise winding, Cross(b-a, c-a)) oriented to agree with the reference direction.
+// If the natural normal points away from reference it is negated and flipped is true.
+func ConsistentNormal(reference, a, b, c *T) (normal T, flipped bool) {
+	ab := Sub(b, a)
+	ac := Sub(c, a)
+	normal = Cross(&ab, &ac)
+	normal.Normalize()
+	if Dot(&normal, reference) < 0 {
+		normal.Invert()
+		flipped = true
+	}
+	return normal, flipped
+}
+
+// InterpolateAttributes blends the per vertex attributes a, b and c of a triangle
+// with the barycentric weights bary, as used for UVs, colors and other vertex data.
+// An error is returned if the attribute slices differ in length.
+func InterpolateAttributes(bary [3]float64, a, b, c []float64) ([]float64, error) {
+	if len(a) != len(b) || len(a) != len(c) {
+		return nil, errors.New("vec3.InterpolateAttributes: attributes differ in length")
+	}
+	result := make([]float64, len(a))
+	for i := range result {
+		result[i] = bary[0]*a[i] + bary[1]*b[i] + bary[2]*c[i]
+	}
+	return result, nil
+}
+
+// ClampBarycentric clamps the barycentric weights u, v and w to be non-negative
+// and renormalizes them to sum to 1, which removes small floating point
+// errors of points on or near the edges of a triangle.
+// If all weights are clamped to zero, the centroid weights 1/3 are returned.
+func ClampBarycentric(u, v, w float64) (float64, float64, float64) {
+	u, v, w = math.Max(u, 0), math.Max(v, 0), math.Max(w, 0)
+	sum := u + v + w
+	if sum == 0 {
+		return 1.0 / 3, 1.0 / 3, 1.0 / 3
+	}
+	return u / sum, v / sum, w / sum
+}
+
+// WindingNumber returns the generalized winding number of the closed triangle mesh
+// at the point p as the sum of the solid angles of the triangles seen from p divided by 4π.
+// The triangles must be wound counter clockwise when looked at from outside.
+// The result is near 1 for points inside and near 0 for points outside of the mesh,
+// which degrades gracefully for meshes with small holes.
+func WindingNumber(p *T, triangles [][3]T) float64 {
+	sum := 0.0
+	for i := range triangles {
+		sum += SolidAngle(p, &triangles[i][0], &triangles[i][1], &triangles[i][2])
+	}
+	return sum / (4 * math.Pi)
+}
+
+// SolidAngle returns the solid angle in steradians that the triangle a, b, c
+// subtends as seen from observer, using the formula of Van Oosterom and Strackee.
+// The result is positive if the normal (b-a)×(c-a) of the triangle
+// points away from observer and negative otherwise.
+// It is zero if observer lies in the plane of the triangle.
+func SolidAngle(observer, a, b, c *T) float64 {
+	pa := Sub(a, observer)
+	pb := Sub(b, observer)
+	pc := Sub(c, observer)
+	la, lb, lc := pa.Length(), pb.Length(), pc.Length()
+	cross := Cross(&pb, &pc)
+	numerator := Dot(&pa, &cross)
+	if numerator == 0 {
+		return 0
+	}
+	denominator := la*lb*lc + Dot(&pa, &pb)*lc + Dot(&pa, &pc)*lb + Dot(&pb, &pc)*la
+	return 2 * math.Atan2(numerator, denominator)
+}
+
+// ClosestPointOnTriangle returns the point of the triangle a, b, c closest to p
+// by testing the Voronoi regions of the vertices, edges and the face.
+// See Christer Ericson, Real-Time Collision Detection, 5.1.5
+func ClosestPointOnTriangle(p, a, b, c *T) T {
+	ab := Sub(b, a)
+	ac := Sub(c, a)
+	ap := Sub(p, a)
+	d1 := Dot(&ab, &ap)
+	d2 := Dot(&ac, &ap)
+	if d1 <= 0 && d2 <= 0 {
+		return *a
+	}
+	bp := Sub(p, b)
+	d3 := Dot(&ab, &bp)
+	d4 := Dot(&ac, &bp)
+	if d3 >= 0 && d4 <= d3 {
+		return *b
+	}
+	vc := d1*d4 - d3*d2
+	if vc <= 0 && d1 >= 0 && d3 <= 0 {
+		return Interpolate(a, b, d1/(d1-d3))
+	}
+	cp := Sub(p, c)
+	d5 := Dot(&ab, &cp)
+	d6 := Dot(&ac, &cp)
+	if d6 >= 0 && d5 <= d6 {
+		return *c
+	}
+	vb := d5*d2 - d1*d6
+	if vb <= 0 && d2 >= 0 && d6 <= 0 {
+		return Interpolate(a, c, d2/(d2-d6))
+	}
+	va := d3*d6 - d5*d4
+	if va <= 0 && d4-d3 >= 0 && d5-d6 >= 0 {
+		return Interpolate(b, c, (d4-d3)/((d4-d3)+(d5-d6)))
+	}
+	denom := 1 / (va + vb + vc)
+	ab.Scale(vb * denom)
+	ac.Scale(vc * denom)
+	result := Add(a, &ab)
+	return *result.Add(&ac)
+}
+
+// ProjectAndClampToTriangle projects p onto the plane of the triangle a, b, c
+// and clamps the projection to the nearest point of the triangle
+// with ClosestPointOnTriangle if it falls outside.
+// For degenerate triangles without a plane the closest point is returned.
+func ProjectAndClampToTriangle(p, a, b, c *T) T {
+	ab := Sub(b, a)
+	ac := Sub(c, a)
+	normal := Cross(&ab, &ac)
+	lengthSqr := normal.LengthSqr()
+	if lengthSqr == 0 {
+		return ClosestPointOnTriangle(p, a, b, c)
+	}
+	ap := Sub(p, a)
+	normal.Scale(Dot(&ap, &normal) / lengthSqr)
+	projected := Sub(p, &normal)
+	return ClosestPointOnTriangle(&projected, a, b, c)
+}
@@ -0,0 +1,190 @@
+package vec3
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/vec2"
+)
+
+func TestComputeTangent(t *testing.T) {
+	p0 := T{0, 0, 0}
+	p1 := T{2, 0, 0}
+	p2 := T{0, 0, -3}
+	uv0 := vec2.T{0, 0}
+	uv1 := vec2.T{1, 0}
+	uv2 := vec2.T{0, 1}
+	tangent, bitangent := ComputeTangent(&p0, &p1, &p2, &uv0, &uv1, &uv2)
+	if Distance(&tangent, &UnitX) > EPSILON {
+		t.Errorf("tangent is %v, want %v", tangent, UnitX)
+	}
+	if want := (T{0, 0, -1}); Distance(&bitangent, &want) > EPSILON {
+		t.Errorf("bitangent is %v, want %v", bitangent, want)
+	}
+
+	tangent, bitangent = ComputeTangent(&p0, &p1, &p2, &uv0, &uv0, &uv2)
+	if !tangent.IsZero() || !bitangent.IsZero() {
+		t.Errorf("degenerate UVs returned tangent %v and bitangent %v", tangent, bitangent)
+	}
+}
+
+func TestDihedralAngle(t *testing.T) {
+	a := T{0, 0, 0}
+	b := T{1, 0, 0}
+	c := T{0.5, 1, 0}
+	flat := T{0.5, -1, 0}
+	if got := DihedralAngle(&a, &b, &c, &flat); math.Abs(math.Abs(got)-math.Pi) > EPSILON {
+		t.Errorf("flat dihedral angle is %v, want Pi", got)
+	}
+	if got := DihedralAngle(&a, &b, &c, &c); math.Abs(got) > EPSILON {
+		t.Errorf("folded dihedral angle is %v, want 0", got)
+	}
+	up := T{0.3, 0, 1}
+	if got := DihedralAngle(&a, &b, &c, &up); math.Abs(got-math.Pi/2) > EPSILON {
+		t.Errorf("dihedral angle is %v, want Pi/2", got)
+	}
+	down := T{0.3, 0, -1}
+	if got := DihedralAngle(&a, &b, &c, &down); math.Abs(got+math.Pi/2) > EPSILON {
+		t.Errorf("dihedral angle is %v, want -Pi/2", got)
+	}
+}
+
+func TestConsistentNormal(t *testing.T) {
+	a := T{0, 0, 0}
+	b := T{2, 0, 0}
+	c := T{0, 2, 0}
+	normal, flipped := ConsistentNormal(&UnitZ, &a, &b, &c)
+	if flipped || normal != UnitZ {
+		t.Errorf("agreeing triangle returned normal %v, flipped=%v", normal, flipped)
+	}
+	normal, flipped = ConsistentNormal(&UnitZ, &a, &c, &b)
+	if !flipped || normal != UnitZ {
+		t.Errorf("disagreeing triangle returned normal %v, flipped=%v", normal, flipped)
+	}
+}
+
+func TestInterpolateAttributes(t *testing.T) {
+	centroid := [3]float64{1.0 / 3, 1.0 / 3, 1.0 / 3}
+
+	uv, err := InterpolateAttributes(centroid, []float64{0, 0}, []float64{1, 0}, []float64{0, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(uv[0]-1.0/3) > EPSILON || math.Abs(uv[1]-1.0/3) > EPSILON {
+		t.Errorf("UV at the centroid is %v, want [1/3 1/3]", uv)
+	}
+
+	color, err := InterpolateAttributes(centroid, []float64{1, 0, 0}, []float64{0, 1, 0}, []float64{0, 0.5, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []float64{1.0 / 3, 0.5, 1.0 / 3}
+	for i := range want {
+		if math.Abs(color[i]-want[i]) > EPSILON {
+			t.Errorf("color at the centroid is %v, want %v", color, want)
+			break
+		}
+	}
+
+	if _, err := InterpolateAttributes(centroid, []float64{1, 2}, []float64{1, 2, 3}, []float64{1, 2}); err == nil {
+		t.Errorf("no error for attributes of different length")
+	}
+}
+
+func TestWindingNumber(t *testing.T) {
+	a, b, c, d := T{0, 0, 0}, T{1, 0, 0}, T{0, 1, 0}, T{0, 0, 1}
+	tetrahedron := [][3]T{
+		{a, c, b},
+		{a, b, d},
+		{a, d, c},
+		{b, c, d},
+	}
+	for _, p := range []T{{0.1, 0.1, 0.1}, {0.25, 0.25, 0.25}, {0.01, 0.5, 0.2}} {
+		if w := WindingNumber(&p, tetrahedron); math.Abs(w-1) > EPSILON {
+			t.Errorf("winding number of the inside point %v is %v, want 1", p, w)
+		}
+	}
+	for _, p := range []T{{1, 1, 1}, {-0.1, 0.1, 0.1}, {5, -3, 2}} {
+		if w := WindingNumber(&p, tetrahedron); math.Abs(w) > EPSILON {
+			t.Errorf("winding number of the outside point %v is %v, want 0", p, w)
+		}
+	}
+	// removing a face leaves a hole, points near the opposite vertex are still mostly inside
+	p := T{0.05, 0.05, 0.8}
+	if w := WindingNumber(&p, tetrahedron[:3]); w < 0.5 {
+		t.Errorf("winding number for an open mesh is %v, want more than 0.5", w)
+	}
+}
+
+func TestSolidAngle(t *testing.T) {
+	a, b, c := UnitX, UnitY, UnitZ
+	// the triangle covers one octant of the sphere around the origin
+	if s := SolidAngle(&Zero, &a, &b, &c); math.Abs(s-math.Pi/2) > EPSILON {
+		t.Errorf("solid angle of the octant triangle is %v, want π/2", s)
+	}
+	if s := SolidAngle(&Zero, &a, &c, &b); math.Abs(s+math.Pi/2) > EPSILON {
+		t.Errorf("solid angle of the reversed octant triangle is %v, want -π/2", s)
+	}
+	// a far away small triangle subtends about its projected area divided by the squared distance
+	far := T{0, 0, -1000}
+	small := [3]T{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}}
+	if s := SolidAngle(&far, &small[0], &small[1], &small[2]); math.Abs(s-0.5e-6) > 1e-9 {
+		t.Errorf("solid angle of the far triangle is %v, want 0.5e-6", s)
+	}
+	for _, p := range []T{{0.2, 0.2, 0}, {5, 5, 0}} {
+		if s := SolidAngle(&p, &small[0], &small[1], &small[2]); s != 0 {
+			t.Errorf("solid angle from %v in the plane of the triangle is %v, want 0", p, s)
+		}
+	}
+}
+
+func TestProjectAndClampToTriangle(t *testing.T) {
+	a := T{0, 0, 1}
+	b := T{4, 0, 1}
+	c := T{0, 4, 1}
+	tests := []struct {
+		p, want T
+	}{
+		// projection lands inside
+		{T{1, 1, 5}, T{1, 1, 1}},
+		{T{1, 2, -3}, T{1, 2, 1}},
+		// projection lands outside the hypotenuse edge
+		{T{3, 3, 2}, T{2, 2, 1}},
+		// outside the edge from a to b
+		{T{2, -1, 0}, T{2, 0, 1}},
+		// outside the vertex a
+		{T{-1, -2, 7}, a},
+	}
+	for _, test := range tests {
+		if got := ProjectAndClampToTriangle(&test.p, &a, &b, &c); Distance(&got, &test.want) > 1e-12 {
+			t.Errorf("ProjectAndClampToTriangle(%v) is %v, want %v", test.p, got, test.want)
+		}
+	}
+	if got := ClosestPointOnTriangle(&T{5, 0, 1}, &a, &b, &c); got != b {
+		t.Errorf("closest point beyond vertex b is %v, want %v", got, b)
+	}
+}
+
+func TestClampBarycentric(t *testing.T) {
+	tests := []struct {
+		in, want [3]float64
+	}{
+		{[3]float64{-1e-9, 0.5, 0.5 + 1e-9}, [3]float64{0, 0.5 / (1 + 1e-9), (0.5 + 1e-9) / (1 + 1e-9)}},
+		{[3]float64{1.1, -0.05, -0.05}, [3]float64{1, 0, 0}},
+		{[3]float64{0.2, 0.3, 0.5}, [3]float64{0.2, 0.3, 0.5}},
+		{[3]float64{-1, -1, -1}, [3]float64{1.0 / 3, 1.0 / 3, 1.0 / 3}},
+	}
+	for _, test := range tests {
+		u, v, w := ClampBarycentric(test.in[0], test.in[1], test.in[2])
+		got := [3]float64{u, v, w}
+		for i := range got {
+			if got[i] < 0 || math.Abs(got[i]-test.want[i]) > 1e-15 {
+				t.Errorf("ClampBarycentric%v is %v, want %v", test.in, got, test.want)
+				break
+			}
+		}
+		if math.Abs(u+v+w-1) > 1e-15 {
+			t.Errorf("ClampBarycentric%v sums to %v", test.in, u+v+w)
+		}
+	}
+}
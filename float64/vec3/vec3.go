@@ -145,6 +145,32 @@ func (vec *T) Absed() T {
 	return T{math.Abs(vec[0]), math.Abs(vec[1]), math.Abs(vec[2])}
 }
 
+// Sign returns a copy of the vector with every component replaced by its sign:
+// -1 for negative, +1 for positive and 0 for zero components.
+// Negative zero also yields 0.
+func (vec *T) Sign() T {
+	var result T
+	for i, v := range vec {
+		if v > 0 {
+			result[i] = 1
+		} else if v < 0 {
+			result[i] = -1
+		}
+	}
+	return result
+}
+
+// CopySign returns a vector with the magnitudes of the components of mag
+// and the signs of the respective components of sign.
+// See math.Copysign for the handling of zero and negative zero.
+func CopySign(mag, sign *T) T {
+	return T{
+		math.Copysign(mag[0], sign[0]),
+		math.Copysign(mag[1], sign[1]),
+		math.Copysign(mag[2], sign[2]),
+	}
+}
+
 // Normalize normalizes the vector to unit length.
 func (vec *T) Normalize() *T {
 	sl := vec.LengthSqr()
@@ -162,6 +188,42 @@ func (vec *T) Normalized() T {
 	return v
 }
 
+// NormalizeSlice normalizes every vector of vs in place to unit length.
+// Zero length vectors are left unchanged.
+func NormalizeSlice(vs []T) {
+	for i := range vs {
+		v := &vs[i]
+		sl := v[0]*v[0] + v[1]*v[1] + v[2]*v[2]
+		if sl == 0 || sl == 1 {
+			continue
+		}
+		f := 1 / math.Sqrt(sl)
+		v[0] *= f
+		v[1] *= f
+		v[2] *= f
+	}
+}
+
+// Recenter returns copies of points shifted so that their centroid is at the origin,
+// and the centroid as origin that has to be added to the recentered points
+// to get the original positions back.
+// Recentering in float64 before converting coordinates far from the origin
+// to float32 preserves their relative precision.
+func Recenter(points []T) (recentered []T, origin T) {
+	if len(points) == 0 {
+		return nil, Zero
+	}
+	for i := range points {
+		origin.Add(&points[i])
+	}
+	origin.Scale(1 / float64(len(points)))
+	recentered = make([]T, len(points))
+	for i := range points {
+		recentered[i] = Sub(&points[i], &origin)
+	}
+	return recentered, origin
+}
+
 // Normal returns an orthogonal vector.
 func (vec *T) Normal() T {
 	n := Cross(vec, &UnitZ)
@@ -236,6 +298,17 @@ func Cross(a, b *T) T {
 	}
 }
 
+// CrossInto writes the cross product of a and b to dst.
+// dst may be the same vector as a or b.
+func CrossInto(dst, a, b *T) {
+	x := a[1]*b[2] - a[2]*b[1]
+	y := a[2]*b[0] - a[0]*b[2]
+	z := a[0]*b[1] - a[1]*b[0]
+	dst[0] = x
+	dst[1] = y
+	dst[2] = z
+}
+
 // Angle returns the angle between two vectors.
 func Angle(a, b *T) float64 {
 	v := Dot(a, b) / (a.Length() * b.Length())
@@ -248,6 +321,23 @@ func Angle(a, b *T) float64 {
 	return math.Acos(v)
 }
 
+// AngleBetween returns the angle in the range [0,π] between two vectors.
+// It uses atan2 of the cross product length and the dot product,
+// which stays accurate for nearly parallel and nearly anti-parallel vectors
+// where the arc cosine used by Angle loses precision.
+// Prefer it over Angle. The vectors don't need to be normalized.
+func AngleBetween(a, b *T) float64 {
+	cross := Cross(a, b)
+	return math.Atan2(cross.Length(), Dot(a, b))
+}
+
+// GreatCircleDistance returns the angular distance in radians between a and b
+// treated as directions from the center of a sphere.
+// It is the same as AngleBetween.
+func GreatCircleDistance(a, b *T) float64 {
+	return AngleBetween(a, b)
+}
+
 // Min returns the component wise minimum of two vectors.
 func Min(a, b *T) T {
 	min := *a
@@ -288,6 +378,34 @@ func Interpolate(a, b *T, t float64) T {
 	}
 }
 
+// LerpVec interpolates component wise between a and b
+// with an individual factor per component in t,
+// computing a + (b-a)*t. See also Interpolate.
+func LerpVec(a, b, t *T) T {
+	return T{
+		a[0] + (b[0]-a[0])*t[0],
+		a[1] + (b[1]-a[1])*t[1],
+		a[2] + (b[2]-a[2])*t[2],
+	}
+}
+
+// Bilinear interpolates between the four corners of a quad at u, v (0,1),
+// where v00 is the corner at u = 0, v = 0 and v10 the corner at u = 1, v = 0.
+func Bilinear(v00, v10, v01, v11 *T, u, v float64) T {
+	a := Interpolate(v00, v10, u)
+	b := Interpolate(v01, v11, u)
+	return Interpolate(&a, &b, v)
+}
+
+// Trilinear interpolates between the eight corners of a cube at u, v, w (0,1).
+// Bit 0 of the corner index selects u = 1 (set) or u = 0,
+// bit 1 v and bit 2 w, so corners[5] is the corner at u = 1, v = 0, w = 1.
+func Trilinear(corners *[8]T, u, v, w float64) T {
+	a := Bilinear(&corners[0], &corners[1], &corners[2], &corners[3], u, v)
+	b := Bilinear(&corners[4], &corners[5], &corners[6], &corners[7], u, v)
+	return Interpolate(&a, &b, w)
+}
+
 // Clamp clamps the vector's components to be in the range of min to max.
 func (vec *T) Clamp(min, max *T) *T {
 	for i := range vec {
@@ -318,3 +436,122 @@ func (vec *T) Clamped01() T {
 	result.Clamp01()
 	return result
 }
+
+// SoftSaturate is a smooth alternative to Clamp01 for tone mapping.
+// Components up to knee are not changed, larger components x are mapped to
+// knee + (1-knee)*(1-exp(-(x-knee)/(1-knee))),
+// which continues with slope 1 at the knee and approaches 1 for large x.
+// knee must be smaller than 1.
+func (vec *T) SoftSaturate(knee float64) *T {
+	r := 1 - knee
+	for i, x := range vec {
+		if x > knee {
+			vec[i] = knee + r*(1-math.Exp(-(x-knee)/r))
+		}
+	}
+	return vec
+}
+
+// SoftSaturated returns a copy of the vector with SoftSaturate applied.
+func (vec *T) SoftSaturated(knee float64) T {
+	result := *vec
+	result.SoftSaturate(knee)
+	return result
+}
+
+// ClampToSphere moves the vector onto the surface of the sphere
+// if it is farther than radius from center, points inside the sphere are not changed.
+func (vec *T) ClampToSphere(center *T, radius float64) *T {
+	d := Sub(vec, center)
+	if lengthSqr := d.LengthSqr(); lengthSqr > radius*radius {
+		d.Scale(radius / math.Sqrt(lengthSqr))
+		*vec = Add(center, &d)
+	}
+	return vec
+}
+
+// ProjectToSphereSurface moves the vector along the direction from center
+// onto the surface of the sphere, whether it is inside or outside of it.
+// A vector that coincides with center has no direction
+// and is moved to center + radius*UnitX.
+func (vec *T) ProjectToSphereSurface(center *T, radius float64) *T {
+	d := Sub(vec, center)
+	lengthSqr := d.LengthSqr()
+	if lengthSqr == 0 {
+		d = UnitX.Scaled(radius)
+	} else {
+		d.Scale(radius / math.Sqrt(lengthSqr))
+	}
+	*vec = Add(center, &d)
+	return vec
+}
+
+// ClampToCone returns dir if its angle to the unit vector axis is at most maxAngle.
+// Otherwise the direction on the boundary of the cone around axis
+// with the half angle maxAngle that is nearest to dir is returned.
+// The length of dir is preserved.
+func ClampToCone(dir, axis *T, maxAngle float64) T {
+	if GreatCircleDistance(dir, axis) <= maxAngle {
+		return *dir
+	}
+	length := dir.Length()
+	perp := axis.Scaled(Dot(dir, axis))
+	perp = Sub(dir, &perp)
+	if perp.LengthSqr() < 1e-24 {
+		// dir is anti-parallel to axis, every boundary direction is equally near
+		perp = axis.Normal()
+	} else {
+		perp.Normalize()
+	}
+	perp.Scale(math.Sin(maxAngle))
+	result := axis.Scaled(math.Cos(maxAngle))
+	result.Add(&perp)
+	return *result.Scale(length)
+}
+
+// RotateTowardsDirection rotates the direction from towards the direction to
+// by the fraction t of the angle between them, using Rodrigues' rotation
+// around their common perpendicular. The result has unit length.
+// If the directions are anti-parallel, an arbitrary perpendicular axis is used,
+// if they are parallel the normalized from is returned.
+func RotateTowardsDirection(from, to *T, t float64) T {
+	f := from.Normalized()
+	g := to.Normalized()
+	axis := Cross(&f, &g)
+	angle := math.Atan2(axis.Length(), Dot(&f, &g))
+	if angle < 1e-12 {
+		return f
+	}
+	if axis.LengthSqr() < 1e-24 {
+		axis, _ = orthonormalBasis(&f)
+	} else {
+		axis.Normalize()
+	}
+	// axis is perpendicular to f, so the axis*Dot(axis, f) term vanishes
+	sin, cos := math.Sincos(angle * t)
+	kf := Cross(&axis, &f)
+	r := f.Scaled(cos)
+	kf.Scale(sin)
+	r.Add(&kf)
+	return r.Normalized()
+}
+
+// FromCylindrical returns the vector for the cylindrical coordinates radius, theta and height.
+// The Y axis is the axis of the cylinder and height is measured along it.
+// theta is the azimuth angle in the XZ plane, measured from the X axis towards the Z axis,
+// so that X = radius*cos(theta) and Z = radius*sin(theta).
+func FromCylindrical(radius, theta, height float64) T {
+	return T{radius * math.Cos(theta), height, radius * math.Sin(theta)}
+}
+
+// Cylindrical returns the cylindrical coordinates of the vector.
+// See FromCylindrical for the convention.
+// theta is in the range -Pi to Pi and zero for points on the Y axis,
+// where the azimuth is undefined.
+func (vec *T) Cylindrical() (radius, theta, height float64) {
+	radius = math.Hypot(vec[0], vec[2])
+	if radius != 0 {
+		theta = math.Atan2(vec[2], vec[0])
+	}
+	return radius, theta, vec[1]
+}
@@ -0,0 +1,333 @@
+package vec3
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGreatCircleDistance(t *testing.T) {
+	if d := GreatCircleDistance(&UnitX, &UnitY); math.Abs(d-math.Pi/2) > EPSILON {
+		t.Errorf("distance between orthogonal vectors is %v, want %v", d, math.Pi/2)
+	}
+	a := T{2, 0, 0}
+	b := T{-3, 0, 0}
+	if d := GreatCircleDistance(&a, &b); math.Abs(d-math.Pi) > EPSILON {
+		t.Errorf("distance between opposite vectors is %v, want %v", d, math.Pi)
+	}
+	const angle = 1e-9
+	c := T{math.Cos(angle), math.Sin(angle), 0}
+	if d := GreatCircleDistance(&UnitX, &c); math.Abs(d-angle) > 1e-15 {
+		t.Errorf("distance between nearly identical vectors is %v, want %v", d, angle)
+	}
+}
+
+func TestAngleBetween(t *testing.T) {
+	const angle = 1e-8
+	nearlyParallel := T{math.Cos(angle), math.Sin(angle), 0}
+	nearlyOpposite := T{-math.Cos(angle), math.Sin(angle), 0}
+	scaled := nearlyParallel.Scaled(3)
+
+	if got := AngleBetween(&UnitX, &scaled); math.Abs(got-angle) > 1e-15 {
+		t.Errorf("angle between nearly parallel vectors is %v, want %v", got, angle)
+	}
+	if got := AngleBetween(&UnitX, &nearlyOpposite); math.Abs(got-(math.Pi-angle)) > 1e-15 {
+		t.Errorf("angle between nearly anti-parallel vectors is %v, want %v", got, math.Pi-angle)
+	}
+	// the arc cosine based Angle is much less precise in these cases
+	acosErr := math.Abs(Angle(&UnitX, &nearlyParallel) - angle)
+	atan2Err := math.Abs(AngleBetween(&UnitX, &nearlyParallel) - angle)
+	if atan2Err > acosErr {
+		t.Errorf("AngleBetween error %v is larger than Angle error %v", atan2Err, acosErr)
+	}
+	acosErr = math.Abs(Angle(&UnitX, &nearlyOpposite) - (math.Pi - angle))
+	atan2Err = math.Abs(AngleBetween(&UnitX, &nearlyOpposite) - (math.Pi - angle))
+	if atan2Err > acosErr {
+		t.Errorf("anti-parallel AngleBetween error %v is larger than Angle error %v", atan2Err, acosErr)
+	}
+	// a smaller deviation of unnormalized anti-parallel vectors, where the arc cosine returns exactly π
+	const tiny = 1e-12
+	opposite := T{-0.25 * math.Cos(tiny), 0, 0.25 * math.Sin(tiny)}
+	a := T{2, 0, 0}
+	if got := AngleBetween(&a, &opposite); math.Abs(got-(math.Pi-tiny)) > 1e-15 {
+		t.Errorf("angle between nearly anti-parallel vectors is %v, want %v", got, math.Pi-tiny)
+	}
+	if got := AngleBetween(&a, &opposite); got == math.Pi {
+		t.Errorf("angle between nearly anti-parallel vectors is rounded to π")
+	}
+	if got := AngleBetween(&UnitX, &UnitZ); math.Abs(got-math.Pi/2) > EPSILON {
+		t.Errorf("angle between orthogonal vectors is %v, want %v", got, math.Pi/2)
+	}
+}
+
+func TestSign(t *testing.T) {
+	v := T{-2.5, 0, 3}
+	if got, want := v.Sign(), (T{-1, 0, 1}); got != want {
+		t.Errorf("Sign of %v is %v, want %v", v, got, want)
+	}
+	v = T{math.Copysign(0, -1), -0.001, 1e300}
+	if got, want := v.Sign(), (T{0, -1, 1}); got != want {
+		t.Errorf("Sign of %v is %v, want %v", v, got, want)
+	}
+}
+
+func TestCopySign(t *testing.T) {
+	mag := T{1, -2, 3}
+	sign := T{-5, 7, 0}
+	if got, want := CopySign(&mag, &sign), (T{-1, 2, 3}); got != want {
+		t.Errorf("CopySign(%v, %v) is %v, want %v", mag, sign, got, want)
+	}
+}
+
+func TestClampToCone(t *testing.T) {
+	const maxAngle = math.Pi / 6
+	inside := T{0.1, 0, 2}
+	if got := ClampToCone(&inside, &UnitZ, maxAngle); got != inside {
+		t.Errorf("direction inside the cone changed from %v to %v", inside, got)
+	}
+
+	outside := T{3, 0, 0}
+	got := ClampToCone(&outside, &UnitZ, maxAngle)
+	want := T{3 * math.Sin(maxAngle), 0, 3 * math.Cos(maxAngle)}
+	if Distance(&got, &want) > EPSILON {
+		t.Errorf("direction outside the cone clamped to %v, want %v", got, want)
+	}
+
+	behind := T{0, 0, -1}
+	got = ClampToCone(&behind, &UnitZ, maxAngle)
+	if a := GreatCircleDistance(&got, &UnitZ); math.Abs(a-maxAngle) > EPSILON || math.Abs(got.Length()-1) > EPSILON {
+		t.Errorf("anti-parallel direction clamped to %v with angle %v", got, a)
+	}
+}
+
+func TestRotateTowardsDirection(t *testing.T) {
+	from := T{1, 0, 0}
+	to := T{0, 3, 4}
+	to.Normalize()
+	angle := math.Pi / 2
+	for _, f := range []float64{0, 0.3, 0.5, 1} {
+		got := RotateTowardsDirection(&from, &to, f)
+		// spherical linear interpolation between orthogonal unit vectors
+		a := from.Scaled(math.Sin((1-f)*angle) / math.Sin(angle))
+		b := to.Scaled(math.Sin(f*angle) / math.Sin(angle))
+		want := Add(&a, &b)
+		if Distance(&got, &want) > EPSILON {
+			t.Errorf("rotation by %v is %v, want %v", f, got, want)
+		}
+		if math.Abs(got.Length()-1) > EPSILON {
+			t.Errorf("rotation by %v is not unit length: %v", f, got)
+		}
+	}
+
+	scaled := T{5, 0, 0}
+	if got := RotateTowardsDirection(&scaled, &from, 0.5); Distance(&got, &from) > EPSILON {
+		t.Errorf("parallel directions give %v, want %v", got, from)
+	}
+	opposite := T{-2, 0, 0}
+	half := RotateTowardsDirection(&from, &opposite, 0.5)
+	if math.Abs(half.Length()-1) > EPSILON || math.Abs(Dot(&half, &from)) > EPSILON {
+		t.Errorf("half way between anti-parallel directions is %v, want a perpendicular unit vector", half)
+	}
+	if got := RotateTowardsDirection(&from, &opposite, 1); Distance(&got, &T{-1, 0, 0}) > EPSILON {
+		t.Errorf("full rotation between anti-parallel directions is %v", got)
+	}
+}
+
+func TestCylindrical(t *testing.T) {
+	if got, want := FromCylindrical(2, math.Pi/2, 3), (T{0, 3, 2}); Distance(&got, &want) > EPSILON {
+		t.Errorf("FromCylindrical is %v, want %v", got, want)
+	}
+	for _, v := range []T{{1, 2, 3}, {-4, 0.5, 1}, {0.1, -7, -0.2}} {
+		radius, theta, height := v.Cylindrical()
+		if got := FromCylindrical(radius, theta, height); Distance(&got, &v) > EPSILON {
+			t.Errorf("round trip of %v returned %v", v, got)
+		}
+	}
+	onAxis := T{0, 5, 0}
+	if radius, theta, height := onAxis.Cylindrical(); radius != 0 || theta != 0 || height != 5 {
+		t.Errorf("cylindrical coordinates of %v are %v %v %v", onAxis, radius, theta, height)
+	}
+}
+
+func TestLerpVec(t *testing.T) {
+	a := T{0, 10, -2}
+	b := T{4, 20, 2}
+	factors := T{0.25, 1, 0}
+	if got, want := LerpVec(&a, &b, &factors), (T{1, 20, -2}); got != want {
+		t.Errorf("LerpVec is %v, want %v", got, want)
+	}
+	uniform := T{0.5, 0.5, 0.5}
+	if got, want := LerpVec(&a, &b, &uniform), Interpolate(&a, &b, 0.5); got != want {
+		t.Errorf("LerpVec with uniform factors is %v, want %v", got, want)
+	}
+}
+
+func TestCrossInto(t *testing.T) {
+	a := T{1, 2, 3}
+	b := T{-4, 5, 0.5}
+	want := Cross(&a, &b)
+	var dst T
+	CrossInto(&dst, &a, &b)
+	if dst != want {
+		t.Errorf("CrossInto returned %v, want %v", dst, want)
+	}
+	aliasA := a
+	CrossInto(&aliasA, &aliasA, &b)
+	if aliasA != want {
+		t.Errorf("CrossInto aliasing a returned %v, want %v", aliasA, want)
+	}
+	aliasB := b
+	CrossInto(&aliasB, &a, &aliasB)
+	if aliasB != want {
+		t.Errorf("CrossInto aliasing b returned %v, want %v", aliasB, want)
+	}
+}
+
+func BenchmarkCross(b *testing.B) {
+	v1 := T{1, 2, 3}
+	v2 := T{-4, 5, 0.5}
+	for i := 0; i < b.N; i++ {
+		v1 = Cross(&v1, &v2)
+	}
+}
+
+func BenchmarkCrossInto(b *testing.B) {
+	v1 := T{1, 2, 3}
+	v2 := T{-4, 5, 0.5}
+	for i := 0; i < b.N; i++ {
+		CrossInto(&v1, &v1, &v2)
+	}
+}
+
+func TestNormalizeSlice(t *testing.T) {
+	vs := []T{{3, 0, 4}, {0, 0, 0}, {-1, 2, -2}, {0, 0, 1}}
+	want := make([]T, len(vs))
+	for i := range vs {
+		want[i] = vs[i].Normalized()
+	}
+	NormalizeSlice(vs)
+	for i := range vs {
+		if vs[i] != want[i] {
+			t.Errorf("element %d normalized to %v, want %v", i, vs[i], want[i])
+		}
+	}
+	if !vs[1].IsZero() {
+		t.Errorf("zero vector changed to %v", vs[1])
+	}
+}
+
+func BenchmarkNormalizeSlice(b *testing.B) {
+	vs := make([]T, 1024)
+	for i := 0; i < b.N; i++ {
+		for j := range vs {
+			vs[j] = T{float64(j), 1, 2}
+		}
+		NormalizeSlice(vs)
+	}
+}
+
+func TestRecenter(t *testing.T) {
+	points := []T{{1e7, 2e7, -3e7}, {1e7 + 1, 2e7 - 2, -3e7 + 0.5}, {1e7 + 0.25, 2e7 + 3, -3e7 - 1}}
+	recentered, origin := Recenter(points)
+	var centroid T
+	for i := range recentered {
+		centroid.Add(&recentered[i])
+	}
+	if centroid.Length() > 1e-6 {
+		t.Errorf("centroid of recentered points is %v, want zero", centroid)
+	}
+	for i := range points {
+		if p := Add(&recentered[i], &origin); Distance(&p, &points[i]) > 1e-6 {
+			t.Errorf("recentered point %d plus origin is %v, want %v", i, p, points[i])
+		}
+	}
+}
+
+func TestClampToSphere(t *testing.T) {
+	center := T{1, 2, 3}
+	inside := T{1.5, 2, 3}
+	on := T{1, 4, 3}
+	outside := T{1, 2, -7}
+
+	if p := inside; *p.ClampToSphere(&center, 2) != inside {
+		t.Errorf("ClampToSphere moved the inside point %v to %v", inside, p)
+	}
+	if p := on; *p.ClampToSphere(&center, 2) != on {
+		t.Errorf("ClampToSphere moved the surface point %v to %v", on, p)
+	}
+	if p, want := outside, (T{1, 2, 1}); Distance(p.ClampToSphere(&center, 2), &want) > EPSILON {
+		t.Errorf("ClampToSphere moved the outside point %v to %v, want %v", outside, p, want)
+	}
+
+	if p, want := inside, (T{3, 2, 3}); Distance(p.ProjectToSphereSurface(&center, 2), &want) > EPSILON {
+		t.Errorf("ProjectToSphereSurface moved the inside point %v to %v, want %v", inside, p, want)
+	}
+	if p := on; Distance(p.ProjectToSphereSurface(&center, 2), &on) > EPSILON {
+		t.Errorf("ProjectToSphereSurface moved the surface point %v to %v", on, p)
+	}
+	if p, want := outside, (T{1, 2, 1}); Distance(p.ProjectToSphereSurface(&center, 2), &want) > EPSILON {
+		t.Errorf("ProjectToSphereSurface moved the outside point %v to %v, want %v", outside, p, want)
+	}
+	if p, want := center, (T{3, 2, 3}); *p.ProjectToSphereSurface(&center, 2) != want {
+		t.Errorf("ProjectToSphereSurface moved the center to %v, want %v", p, want)
+	}
+}
+
+func TestBilinearTrilinear(t *testing.T) {
+	var corners [8]T
+	var average T
+	for i := range corners {
+		corners[i] = T{float64(i), float64(i * i), -float64(i)}
+		average.Add(&corners[i])
+	}
+	average.Scale(1.0 / 8)
+
+	for i := range corners {
+		u, v, w := float64(i&1), float64(i>>1&1), float64(i>>2&1)
+		if got := Trilinear(&corners, u, v, w); Distance(&got, &corners[i]) > EPSILON {
+			t.Errorf("Trilinear at corner %d is %v, want %v", i, got, corners[i])
+		}
+	}
+	if got := Trilinear(&corners, 0.5, 0.5, 0.5); Distance(&got, &average) > EPSILON {
+		t.Errorf("Trilinear at the center is %v, want %v", got, average)
+	}
+
+	c := corners[:4]
+	for i := range c {
+		u, v := float64(i&1), float64(i>>1)
+		if got := Bilinear(&c[0], &c[1], &c[2], &c[3], u, v); Distance(&got, &c[i]) > EPSILON {
+			t.Errorf("Bilinear at corner %d is %v, want %v", i, got, c[i])
+		}
+	}
+	want := T{1.5, 3.5, -1.5}
+	if got := Bilinear(&c[0], &c[1], &c[2], &c[3], 0.5, 0.5); Distance(&got, &want) > EPSILON {
+		t.Errorf("Bilinear at the center is %v, want %v", got, want)
+	}
+}
+
+func TestSoftSaturate(t *testing.T) {
+	v := T{-0.5, 0.2, 0.8}
+	if got := v.SoftSaturated(0.8); got != v {
+		t.Errorf("SoftSaturated changed values below the knee: %v", got)
+	}
+
+	last := 0.8
+	for _, x := range []float64{0.81, 1, 2, 5, 20, 1e6} {
+		s := T{x, x, x}
+		s.SoftSaturate(0.8)
+		if s[0] < last || s[0] > 1 {
+			t.Errorf("SoftSaturate(%v) is %v after %v, want an increasing value below 1", x, s[0], last)
+		}
+		last = s[0]
+	}
+	if last < 1-EPSILON {
+		t.Errorf("SoftSaturate of very large values is %v, want 1", last)
+	}
+	// the slope is continuous at the knee
+	const h = 1e-6
+	a, b := T{0.8 + h}, T{0.8 + 2*h}
+	a.SoftSaturate(0.8)
+	b.SoftSaturate(0.8)
+	if slope := (b[0] - a[0]) / h; math.Abs(slope-1) > 1e-4 {
+		t.Errorf("slope above the knee is %v, want 1", slope)
+	}
+}
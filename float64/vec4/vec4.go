@@ -6,6 +6,7 @@ import (
 	"math"
 
 	"github.com/ungerik/go3d/float64/generic"
+	"github.com/ungerik/go3d/float64/vec2"
 	"github.com/ungerik/go3d/float64/vec3"
 )
 
@@ -751,6 +752,32 @@ func (vec *T) AssignVec3(v *vec3.T) *T {
 	return vec
 }
 
+// XYZ returns a vec3.T with the X, Y and Z components of the vector.
+// It is the same as Vec3.
+func (vec *T) XYZ() vec3.T {
+	return vec3.T{vec[0], vec[1], vec[2]}
+}
+
+// XY returns a vec2.T with the X and Y components of the vector.
+func (vec *T) XY() vec2.T {
+	return vec2.T{vec[0], vec[1]}
+}
+
+// RGB returns a vec3.T with the red, green and blue components
+// of the vector interpreted as RGBA color.
+func (vec *T) RGB() vec3.T {
+	return vec3.T{vec[0], vec[1], vec[2]}
+}
+
+// SetXYZ sets the X, Y and Z components of the vector to v
+// and leaves W unchanged. See also AssignVec3.
+func (vec *T) SetXYZ(v *vec3.T) *T {
+	vec[0] = v[0]
+	vec[1] = v[1]
+	vec[2] = v[2]
+	return vec
+}
+
 // Add adds another vector to vec.
 func (vec *T) Add(v *T) *T {
 	if v[3] == vec[3] {
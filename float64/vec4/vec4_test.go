@@ -0,0 +1,25 @@
+package vec4
+
+import (
+	"testing"
+
+	"github.com/ungerik/go3d/float64/vec2"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+func TestSwizzle(t *testing.T) {
+	v := T{1, 2, 3, 4}
+	if got, want := v.XYZ(), (vec3.T{1, 2, 3}); got != want {
+		t.Errorf("XYZ is %v, want %v", got, want)
+	}
+	if got, want := v.XY(), (vec2.T{1, 2}); got != want {
+		t.Errorf("XY is %v, want %v", got, want)
+	}
+	if got, want := v.RGB(), (vec3.T{1, 2, 3}); got != want {
+		t.Errorf("RGB is %v, want %v", got, want)
+	}
+	xyz := vec3.T{7, 8, 9}
+	if got, want := *v.SetXYZ(&xyz), (T{7, 8, 9, 4}); got != want {
+		t.Errorf("SetXYZ gives %v, want %v", got, want)
+	}
+}